@@ -24,19 +24,30 @@ type AccessSectionInfo struct {
 // Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-access.html#permission-info
 type PermissionInfo struct {
 	Label     string                        `json:"label,omitempty"`
-	Exclusive bool                          `json:"exclusive"`
-	Rules     map[string]PermissionRuleInfo `json:"rules"`
+	Exclusive bool                          `json:"exclusive,omitempty"`
+	Rules     map[string]PermissionRuleInfo `json:"rules,omitempty"`
 }
 
+// PermissionAction is the action of a permission rule.
+type PermissionAction string
+
+const (
+	PermissionActionAllow       PermissionAction = "ALLOW"
+	PermissionActionDeny        PermissionAction = "DENY"
+	PermissionActionBlock       PermissionAction = "BLOCK"
+	PermissionActionInteractive PermissionAction = "INTERACTIVE"
+	PermissionActionBatch       PermissionAction = "BATCH"
+)
+
 // PermissionRuleInfo entity contains information about a permission rule that is assigned to group.
+// INTERACTIVE and BATCH only apply to the Priority global capability.
 //
 // Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-access.html#permission-rule-info
 type PermissionRuleInfo struct {
-	// TODO Possible values for action: ALLOW, DENY or BLOCK, INTERACTIVE and BATCH
-	Action string `json:"action"`
-	Force  bool   `json:"force"`
-	Min    int    `json:"min"`
-	Max    int    `json:"max"`
+	Action PermissionAction `json:"action"`
+	Force  bool             `json:"force,omitempty"`
+	Min    int              `json:"min"`
+	Max    int              `json:"max"`
 }
 
 // ProjectAccessInfo entity contains information about the access rights for a project.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 )
 
 // RevisionKind describes the change kind.
@@ -238,7 +239,27 @@ type RestoreInput struct {
 
 // RevertInput entity contains information for reverting a change.
 type RevertInput struct {
-	Message string `json:"message,omitempty"`
+	Message        string                       `json:"message,omitempty"`
+	Notify         string                       `json:"notify,omitempty"`
+	NotifyDetails  map[RecipientType]NotifyInfo `json:"notify_details,omitempty"`
+	Topic          string                       `json:"topic,omitempty"`
+	WorkInProgress bool                         `json:"work_in_progress,omitempty"`
+}
+
+// RevertSubmissionInfo entity describes the revert changes created by reverting a submission.
+type RevertSubmissionInfo struct {
+	RevertChanges []ChangeInfo `json:"revert_changes"`
+}
+
+// PureRevertInfo entity describes the result of a pure revert check.
+type PureRevertInfo struct {
+	IsPureRevert bool `json:"is_pure_revert"`
+}
+
+// PureRevertOptions specifies the parameters for the GetPureRevert call.
+type PureRevertOptions struct {
+	// The commit the change claims to revert. Defaults to the commit the change was created as a revert of.
+	ClaimedOriginal string `url:"o,omitempty"`
 }
 
 // ReviewInfo entity contains information about a review.
@@ -289,6 +310,12 @@ type SubmitInput struct {
 	WaitForMerge  bool                         `json:"wait_for_merge,omitempty"`
 }
 
+// SubmittedTogetherInfo entity contains information about a collection of changes that would be submitted together.
+type SubmittedTogetherInfo struct {
+	Changes           []ChangeInfo `json:"changes"`
+	NonVisibleChanges int          `json:"non_visible_changes,omitempty"`
+}
+
 // SubmitInfo entity contains information about the change status after submitting.
 type SubmitInfo struct {
 	Status     string `json:"status"`
@@ -303,8 +330,11 @@ type RuleInput struct {
 
 // ReviewerInput entity contains information for adding a reviewer to a change.
 type ReviewerInput struct {
-	Reviewer  string `json:"reviewer"`
-	Confirmed bool   `json:"confirmed,omitempty"`
+	Reviewer      string                       `json:"reviewer"`
+	State         string                       `json:"state,omitempty"`
+	Confirmed     bool                         `json:"confirmed,omitempty"`
+	Notify        string                       `json:"notify,omitempty"`
+	NotifyDetails map[RecipientType]NotifyInfo `json:"notify_details,omitempty"`
 }
 
 // ReviewInput entity contains information for adding a review to a revision.
@@ -325,6 +355,7 @@ type ReviewInput struct {
 	AddToAttentionSet                []AttentionSetInput            `json:"add_to_attention_set,omitempty"`
 	RemoveFromAttentionSet           []AttentionSetInput            `json:"remove_from_attention_set,omitempty"`
 	IgnoreAutomaticAttentionSetRules bool                           `json:"ignore_automatic_attention_set_rules,omitempty"`
+	NotifyDetails                    map[RecipientType]NotifyInfo   `json:"notify_details,omitempty"`
 }
 
 // RelatedChangeAndCommitInfo entity contains information about a related change and commit.
@@ -410,7 +441,7 @@ type RobotCommentInfo struct {
 type FixSuggestionInfo struct {
 	// The UUID of the suggested fix. It will be generated automatically and
 	// hence will be ignored if it’s set for input objects.
-	FixID string `json:"fix_id"`
+	FixID string `json:"fix_id,omitempty"`
 	// A description of the suggested fix.
 	Description string `json:"description"`
 	// A list of FixReplacementInfo entities indicating how the content of one or
@@ -468,57 +499,65 @@ type ChangeInput struct {
 	Author            *AccountInput          `json:"author,omitempty"`
 	Notify            string                 `json:"notify,omitempty"`
 	NotifyDetails     string                 `json:"notify_details,omitempty"`
+	Patch             *ApplyPatchInput       `json:"patch,omitempty"`
+}
+
+// ApplyPatchInput entity contains information about a patch to apply.
+type ApplyPatchInput struct {
+	Patch string `json:"patch"`
 }
 
 // ChangeInfo entity contains information about a change.
 type ChangeInfo struct {
-	ID                     string                      `json:"id"`
-	URL                    string                      `json:"url,omitempty"`
-	Project                string                      `json:"project"`
-	Branch                 string                      `json:"branch"`
-	Topic                  string                      `json:"topic,omitempty"`
-	AttentionSet           map[string]AttentionSetInfo `json:"attention_set,omitempty"`
-	Assignee               AccountInfo                 `json:"assignee,omitempty"`
-	Hashtags               []string                    `json:"hashtags,omitempty"`
-	ChangeID               string                      `json:"change_id"`
-	Subject                string                      `json:"subject"`
-	Status                 string                      `json:"status"`
-	Created                Timestamp                   `json:"created"`
-	Updated                Timestamp                   `json:"updated"`
-	Submitted              *Timestamp                  `json:"submitted,omitempty"`
-	Submitter              AccountInfo                 `json:"submitter,omitempty"`
-	Starred                bool                        `json:"starred,omitempty"`
-	Reviewed               bool                        `json:"reviewed,omitempty"`
-	SubmitType             string                      `json:"submit_type,omitempty"`
-	Mergeable              bool                        `json:"mergeable,omitempty"`
-	Submittable            bool                        `json:"submittable,omitempty"`
-	Insertions             int                         `json:"insertions"`
-	Deletions              int                         `json:"deletions"`
-	TotalCommentCount      int                         `json:"total_comment_count,omitempty"`
-	UnresolvedCommentCount int                         `json:"unresolved_comment_count,omitempty"`
-	Number                 int                         `json:"_number"`
-	Owner                  AccountInfo                 `json:"owner"`
-	Actions                map[string]ActionInfo       `json:"actions,omitempty"`
-	Labels                 map[string]LabelInfo        `json:"labels,omitempty"`
-	PermittedLabels        map[string][]string         `json:"permitted_labels,omitempty"`
-	RemovableReviewers     []AccountInfo               `json:"removable_reviewers,omitempty"`
-	Reviewers              map[string][]AccountInfo    `json:"reviewers,omitempty"`
-	PendingReviewers       map[string][]AccountInfo    `json:"pending_reviewers,omitempty"`
-	ReviewerUpdates        []ReviewerUpdateInfo        `json:"reviewer_updates,omitempty"`
-	Messages               []ChangeMessageInfo         `json:"messages,omitempty"`
-	CurrentRevision        string                      `json:"current_revision,omitempty"`
-	Revisions              map[string]RevisionInfo     `json:"revisions,omitempty"`
-	MoreChanges            bool                        `json:"_more_changes,omitempty"`
-	Problems               []ProblemInfo               `json:"problems,omitempty"`
-	IsPrivate              bool                        `json:"is_private,omitempty"`
-	WorkInProgress         bool                        `json:"work_in_progress,omitempty"`
-	HasReviewStarted       bool                        `json:"has_review_started,omitempty"`
-	RevertOf               int                         `json:"revert_of,omitempty"`
-	SubmissionID           string                      `json:"submission_id,omitempty"`
-	CherryPickOfChange     int                         `json:"cherry_pick_of_change,omitempty"`
-	CherryPickOfPatchSet   int                         `json:"cherry_pick_of_patch_set,omitempty"`
-	ContainsGitConflicts   bool                        `json:"contains_git_conflicts,omitempty"`
-	BaseChange             string                      `json:"base_change,omitempty"`
+	ID                     string                        `json:"id"`
+	URL                    string                        `json:"url,omitempty"`
+	Project                string                        `json:"project"`
+	Branch                 string                        `json:"branch"`
+	Topic                  string                        `json:"topic,omitempty"`
+	AttentionSet           map[string]AttentionSetInfo   `json:"attention_set,omitempty"`
+	Assignee               AccountInfo                   `json:"assignee,omitempty"`
+	Hashtags               []string                      `json:"hashtags,omitempty"`
+	ChangeID               string                        `json:"change_id"`
+	Subject                string                        `json:"subject"`
+	Status                 string                        `json:"status"`
+	Created                Timestamp                     `json:"created"`
+	Updated                Timestamp                     `json:"updated"`
+	Submitted              *Timestamp                    `json:"submitted,omitempty"`
+	Submitter              AccountInfo                   `json:"submitter,omitempty"`
+	Starred                bool                          `json:"starred,omitempty"`
+	Reviewed               bool                          `json:"reviewed,omitempty"`
+	SubmitType             string                        `json:"submit_type,omitempty"`
+	Mergeable              bool                          `json:"mergeable,omitempty"`
+	Submittable            bool                          `json:"submittable,omitempty"`
+	SubmitRequirements     []SubmitRequirementResultInfo `json:"submit_requirements,omitempty"`
+	Insertions             int                           `json:"insertions"`
+	Deletions              int                           `json:"deletions"`
+	TotalCommentCount      int                           `json:"total_comment_count,omitempty"`
+	UnresolvedCommentCount int                           `json:"unresolved_comment_count,omitempty"`
+	Number                 int                           `json:"_number"`
+	Owner                  AccountInfo                   `json:"owner"`
+	Actions                map[string]ActionInfo         `json:"actions,omitempty"`
+	Labels                 map[string]LabelInfo          `json:"labels,omitempty"`
+	PermittedLabels        map[string][]string           `json:"permitted_labels,omitempty"`
+	RemovableReviewers     []AccountInfo                 `json:"removable_reviewers,omitempty"`
+	Reviewers              map[string][]AccountInfo      `json:"reviewers,omitempty"`
+	PendingReviewers       map[string][]AccountInfo      `json:"pending_reviewers,omitempty"`
+	ReviewerUpdates        []ReviewerUpdateInfo          `json:"reviewer_updates,omitempty"`
+	Messages               []ChangeMessageInfo           `json:"messages,omitempty"`
+	CurrentRevision        string                        `json:"current_revision,omitempty"`
+	Revisions              map[string]RevisionInfo       `json:"revisions,omitempty"`
+	MoreChanges            bool                          `json:"_more_changes,omitempty"`
+	Problems               []ProblemInfo                 `json:"problems,omitempty"`
+	IsPrivate              bool                          `json:"is_private,omitempty"`
+	WorkInProgress         bool                          `json:"work_in_progress,omitempty"`
+	HasReviewStarted       bool                          `json:"has_review_started,omitempty"`
+	RevertOf               int                           `json:"revert_of,omitempty"`
+	SubmissionID           string                        `json:"submission_id,omitempty"`
+	CherryPickOfChange     int                           `json:"cherry_pick_of_change,omitempty"`
+	CherryPickOfPatchSet   int                           `json:"cherry_pick_of_patch_set,omitempty"`
+	ContainsGitConflicts   bool                          `json:"contains_git_conflicts,omitempty"`
+	BaseChange             string                        `json:"base_change,omitempty"`
+	MetaRevID              string                        `json:"meta_rev_id,omitempty"`
 }
 
 // LabelInfo entity contains information about a label on a change, always corresponding to the current patch set.
@@ -646,6 +685,29 @@ type ChangeService struct {
 	gerrit *Gerrit
 }
 
+// ChangeOperationResult reports the outcome of a batch operation for one change.
+type ChangeOperationResult struct {
+	ChangeNumber int
+
+	// Change is the change as returned by the operation, if any.
+	Change *ChangeInfo
+
+	// Skipped is true when the operation was not attempted, e.g. because the change is closed
+	// or an operation it depends on failed. Reason tells why.
+	Skipped bool
+	Reason  string
+
+	Err error
+}
+
+func NewChange(gerrit *Gerrit, changeID string) *Change {
+	return &Change{
+		Raw:    new(ChangeInfo),
+		gerrit: gerrit,
+		Base:   changeID,
+	}
+}
+
 // Query lists changes visible to the caller.
 // The query string must be provided by the q parameter.
 // The n parameter can be used to limit the returned results.
@@ -657,11 +719,32 @@ func (s *ChangeService) Query(ctx context.Context, opt *QueryChangeOptions) (*[]
 	return v, resp, err
 }
 
+// queryAll runs a single query and follows pagination until all matching changes are retrieved.
+func (s *ChangeService) queryAll(ctx context.Context, opt *QueryChangeOptions) ([]ChangeInfo, *http.Response, error) {
+	var (
+		changes []ChangeInfo
+		resp    *http.Response
+	)
+	for {
+		opt.Start = len(changes)
+		page, r, err := s.Query(ctx, opt)
+		resp = r
+		if err != nil {
+			return nil, resp, err
+		}
+		changes = append(changes, *page...)
+		if len(*page) == 0 || !(*page)[len(*page)-1].MoreChanges {
+			break
+		}
+	}
+	return changes, resp, nil
+}
+
 // Get retrieves a change.
 //
 // Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#get-change
 func (s *ChangeService) Get(ctx context.Context, changeID string, AdditionalFields ...string) (*Change, *http.Response, error) {
-	change := Change{Raw: new(ChangeInfo), gerrit: s.gerrit, Base: changeID}
+	change := Change{Raw: new(ChangeInfo), gerrit: s.gerrit, Base: NormalizeChangeID(changeID)}
 
 	opt := new(ChangeOptions)
 	opt.AdditionalFields = append(opt.AdditionalFields, AdditionalFields...)
@@ -684,6 +767,41 @@ func (s *ChangeService) Create(ctx context.Context, input *ChangeInput) (*Change
 	return obj.Create(ctx, input)
 }
 
+// RevertSubmission reverts all changes that were merged by the submission with the given ID, e.g. ChangeInfo.SubmissionID.
+//
+// The revert changes are created with Change.RevertSubmission and share a topic,
+// which defaults to "revert-" followed by the submission ID.
+// The changes of the submission are returned along with the reverts.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#revert-submission
+func (s *ChangeService) RevertSubmission(ctx context.Context, submissionID string, input *RevertInput) (*RevertSubmissionInfo, []ChangeInfo, *http.Response, error) {
+	opt := &QueryChangeOptions{}
+	opt.Query = []string{fmt.Sprintf("submissionid:%s", strconv.Quote(submissionID))}
+
+	changes, resp, err := s.Query(ctx, opt)
+	if err != nil {
+		return nil, nil, resp, err
+	}
+	if len(*changes) == 0 {
+		return nil, nil, resp, fmt.Errorf("no changes found for submission %s", submissionID)
+	}
+
+	in := RevertInput{}
+	if input != nil {
+		in = *input
+	}
+	if in.Topic == "" {
+		in.Topic = "revert-" + submissionID
+	}
+
+	change := NewChange(s.gerrit, (*changes)[0].ID)
+	v, resp, err := change.RevertSubmission(ctx, &in)
+	if err != nil {
+		return nil, *changes, resp, err
+	}
+	return v, *changes, resp, nil
+}
+
 // Delete deletes a new or abandoned change
 // New or abandoned changes can be deleted by their owner if the user is granted the Delete Own Changes
 // permission, otherwise only by administrators.
@@ -889,6 +1007,38 @@ func (c *Change) Revert(ctx context.Context, input *RevertInput) (*ChangeInfo, *
 	return c.operate(ctx, "revert", input)
 }
 
+// RevertSubmission creates open revert changes for all of the changes of a certain submission.
+//
+// The changes are reverted in reverse topological order, each revert being based on the previous one,
+// and get a shared topic. The request body does not need to include a RevertInput entity.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#revert-submission
+func (c *Change) RevertSubmission(ctx context.Context, input *RevertInput) (*RevertSubmissionInfo, *http.Response, error) {
+	v := new(RevertSubmissionInfo)
+	u := fmt.Sprintf("changes/%s/revert_submission", c.Base)
+
+	resp, err := c.gerrit.Requester.Call(ctx, "POST", u, input, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// GetPureRevert checks if the given change is a pure revert of the change it references in revertOf.
+// A pure revert is a change that exactly reverts the original change, without any further modification.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#get-pure-revert
+func (c *Change) GetPureRevert(ctx context.Context, opt *PureRevertOptions) (*PureRevertInfo, *http.Response, error) {
+	v := new(PureRevertInfo)
+	u := fmt.Sprintf("changes/%s/pure_revert", c.Base)
+
+	resp, err := c.gerrit.Requester.Call(ctx, "GET", u, opt, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
 // Submit Submits a change.
 //
 // The request body only needs to include a SubmitInput entity if submitting on behalf of another user.
@@ -953,15 +1103,23 @@ func (c *Change) operate(ctx context.Context, tail string, input interface{}) (*
 	return v, resp, nil
 }
 
-// SubmittedTogether returns a list of all changes which are submitted when {submit} is called for this change, including the current change itself.
+// SubmittedTogether returns all changes which are submitted when {submit} is called for this change, including the current change itself.
 // An empty list is returned if this change will be submitted by itself (no other changes).
 //
+// The number of changes that would be submitted together but are not visible to the caller is reported in NonVisibleChanges.
+//
 // Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#submitted_together
-func (c *Change) SubmittedTogether(ctx context.Context) (*[]ChangeInfo, *http.Response, error) {
+func (c *Change) SubmittedTogether(ctx context.Context, opt *ChangeOptions) (*SubmittedTogetherInfo, *http.Response, error) {
 	u := fmt.Sprintf("changes/%s/submitted_together", c.Base)
 
-	v := new([]ChangeInfo)
-	resp, err := c.gerrit.Requester.Call(ctx, "GET", u, nil, v)
+	// Without NON_VISIBLE_CHANGES the server answers with a bare list of changes.
+	o := &ChangeOptions{AdditionalFields: []string{"NON_VISIBLE_CHANGES"}}
+	if opt != nil {
+		o.AdditionalFields = append(o.AdditionalFields, opt.AdditionalFields...)
+	}
+
+	v := new(SubmittedTogetherInfo)
+	resp, err := c.gerrit.Requester.Call(ctx, "GET", u, o, v)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -0,0 +1,267 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FileEdit describes the new state of a file of a change created with ChangeService.CreateFromEdits.
+type FileEdit struct {
+	// Content is the new content of the file. Content that is not valid UTF-8 is uploaded as binary.
+	Content []byte
+
+	// Delete removes the file.
+	Delete bool
+
+	// RenameFrom is the old path of a renamed file. The content of the file is kept when Content is nil.
+	RenameFrom string
+}
+
+// CreateChangeFromEditsInput describes a change created with ChangeService.CreateFromEdits.
+type CreateChangeFromEditsInput struct {
+	// ChangeInput describes the change to create: its project, branch, base, topic, etc.
+	// Its Subject defaults to the first line of Message.
+	ChangeInput
+
+	// Message is the full commit message. It defaults to the subject of the change.
+	// The Change-Id footer of the change is appended when the message has none.
+	// When the server applies the patch, Message is sent as ChangeInput.Subject instead,
+	// which Gerrit takes as the whole commit message, and replaces the subject given in ChangeInput.
+	Message string
+
+	// Patch is a unified diff, as produced by "git diff", that is applied to the base of the change.
+	Patch string
+
+	// Edits maps the paths of the files to change to their new state.
+	Edits map[string]FileEdit
+
+	// Notify is the notify handling of creating the change when the server applies the patch,
+	// and of publishing the change edit otherwise.
+	Notify string
+}
+
+// CreateFromEdits creates a change with content: either a unified diff or a set of file edits.
+//
+// Servers that support it (3.8 and later) apply a patch themselves through the patch field of ChangeInput.
+// Otherwise the change is created empty, the patch is applied locally to the files of the base revision,
+// every file is updated in a change edit, the commit message is set, and the edit is published.
+// When one of these steps fails, the change edit and the change are deleted again.
+// When there is nothing to edit, e.g. a patch without content changes and no new message, the empty change is returned as created.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#create-change
+func (s *ChangeService) CreateFromEdits(ctx context.Context, input *CreateChangeFromEditsInput) (*Change, *http.Response, error) {
+	if input == nil || (input.Patch == "" && len(input.Edits) == 0) {
+		return nil, nil, errors.New("create change: neither a patch nor file edits are given")
+	}
+	if input.Patch != "" && len(input.Edits) > 0 {
+		return nil, nil, errors.New("create change: a patch and file edits are mutually exclusive")
+	}
+
+	in := input.ChangeInput
+	if in.Subject == "" {
+		in.Subject = strings.SplitN(strings.TrimSpace(input.Message), "\n", 2)[0]
+	}
+
+	edits := input.Edits
+	if input.Patch != "" {
+		version, resp, err := s.gerrit.Config.GetVersion(ctx)
+		if err != nil {
+			return nil, resp, err
+		}
+		if serverVersionAtLeast(version, 3, 8) {
+			in.Patch = &ApplyPatchInput{Patch: input.Patch}
+			// Gerrit takes the subject of a ChangeInput as the commit message, footers included.
+			if input.Message != "" {
+				in.Subject = input.Message
+			}
+			if input.Notify != "" {
+				in.Notify = input.Notify
+			}
+			return s.Create(ctx, &in)
+		}
+
+		edits, resp, err = s.editsFromPatch(ctx, &in, input.Patch)
+		if err != nil {
+			return nil, resp, err
+		}
+	}
+
+	change, resp, err := s.Create(ctx, &in)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	// Without file edits or a new message no change edit exists, and the empty change is all there is to create.
+	editMessage := input.Message != "" && input.Message != in.Subject
+	if !hasFileEdits(edits) && !editMessage {
+		return change, resp, nil
+	}
+
+	resp, err = change.applyFileEdits(ctx, edits)
+	if err == nil && editMessage {
+		message := input.Message
+		if !strings.Contains(message, "\nChange-Id:") {
+			message = strings.TrimRight(message, "\n") + "\n\nChange-Id: " + change.Raw.ChangeID + "\n"
+		}
+		resp, err = change.ChangeCommitMessageInChangeEdit(ctx, &ChangeEditMessageInput{Message: message})
+	}
+	if err == nil {
+		resp, err = change.PublishChangeEdit(ctx, &PublishChangeEditInput{Notify: input.Notify})
+	}
+	if err != nil {
+		// Roll back, the errors are secondary to the one that made us give up.
+		_, _ = change.DeleteChangeEdit(ctx)
+		_, _, _ = change.Delete(ctx)
+		return nil, resp, err
+	}
+
+	resp, err = change.Poll(ctx, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+	return change, resp, nil
+}
+
+// hasFileEdits reports whether applying edits changes any file, unlike e.g. the edits of a patch that only changes file modes.
+func hasFileEdits(edits map[string]FileEdit) bool {
+	for path, edit := range edits {
+		if edit.Delete || edit.Content != nil || (edit.RenameFrom != "" && edit.RenameFrom != path) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyFileEdits applies edits to the change edit of the change: renames first, then deletions and new content.
+func (c *Change) applyFileEdits(ctx context.Context, edits map[string]FileEdit) (*http.Response, error) {
+	paths := make([]string, 0, len(edits))
+	for path := range edits {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var (
+		resp *http.Response
+		err  error
+	)
+	for _, path := range paths {
+		if from := edits[path].RenameFrom; from != "" && from != path {
+			resp, err = c.RenameChangeEdit(ctx, &RenameChangeEditInput{OldPath: from, NewPath: path})
+			if err != nil {
+				return resp, fmt.Errorf("rename %s to %s: %w", from, path, err)
+			}
+		}
+	}
+	for _, path := range paths {
+		edit := edits[path]
+		switch {
+		case edit.Delete:
+			resp, err = c.DeleteFileInChangeEdit(ctx, path)
+		case edit.Content == nil:
+			continue
+		case utf8.Valid(edit.Content):
+			resp, err = c.ChangeFileContentInChangeEdit(ctx, path, string(edit.Content))
+		default:
+			_, resp, err = c.ChangeBinaryFileContentInChangeEdit(ctx, path, edit.Content, 0)
+		}
+		if err != nil {
+			return resp, fmt.Errorf("edit %s: %w", path, err)
+		}
+	}
+	return resp, nil
+}
+
+// editsFromPatch applies a unified diff locally to the files of the revision the change will be based on.
+func (s *ChangeService) editsFromPatch(ctx context.Context, input *ChangeInput, patch string) (map[string]FileEdit, *http.Response, error) {
+	files, err := parseUnifiedDiff(patch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	base := input.BaseCommit
+	if base == "" && input.BaseChange != "" {
+		info, resp, err := NewChange(s.gerrit, input.BaseChange).GetDetail(ctx, &ChangeOptions{AdditionalFields: []string{"CURRENT_REVISION"}})
+		if err != nil {
+			return nil, resp, err
+		}
+		base = info.CurrentRevision
+	}
+
+	project := NewProject(s.gerrit, input.Project)
+	content := func(path string) ([]byte, *http.Response, error) {
+		if base != "" {
+			return (&Commit{project: project, gerrit: s.gerrit, Base: base}).GetContent(ctx, path)
+		}
+		encoded, resp, err := (&Branch{project: project, gerrit: s.gerrit, Base: input.Branch}).GetContent(ctx, path)
+		if err != nil {
+			return nil, resp, err
+		}
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, resp, fmt.Errorf("decode %s: %w", path, err)
+		}
+		return decoded, resp, nil
+	}
+
+	var resp *http.Response
+	edits := make(map[string]FileEdit, len(files))
+	for _, file := range files {
+		if file.IsDelete {
+			edits[file.OldPath] = FileEdit{Delete: true}
+			continue
+		}
+
+		edit := FileEdit{}
+		if file.IsRename && file.OldPath != file.NewPath {
+			edit.RenameFrom = file.OldPath
+		}
+		if len(file.Hunks) == 0 && !file.IsNew {
+			// A pure rename or mode change.
+			edits[file.NewPath] = edit
+			continue
+		}
+
+		var old []byte
+		if !file.IsNew {
+			old, resp, err = content(file.OldPath)
+			if err != nil {
+				return nil, resp, fmt.Errorf("get %s: %w", file.OldPath, err)
+			}
+		}
+
+		if edit.Content, err = file.apply(old); err != nil {
+			return nil, resp, err
+		}
+		edits[file.NewPath] = edit
+	}
+
+	return edits, resp, nil
+}
+
+// serverVersionAtLeast reports whether a version returned by ConfigService.GetVersion, e.g. "3.9.1-21-g3f5a1e0", is major.minor or later.
+func serverVersionAtLeast(version string, major, minor int) bool {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	maj, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	digits := strings.IndexFunc(parts[1], func(r rune) bool { return r < '0' || r > '9' })
+	if digits >= 0 {
+		parts[1] = parts[1][:digits]
+	}
+	mnr, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return maj > major || (maj == major && mnr >= minor)
+}
@@ -0,0 +1,168 @@
+package gerrit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestServerVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"3.8.0", true},
+		{"3.9.1-21-g3f5a1e0", true},
+		{"3.10-rc1", true},
+		{"4.0", true},
+		{"3.7.4", false},
+		{"2.16.28", false},
+		{"3", false},
+		{"unknown", false},
+	}
+	for _, tt := range tests {
+		if got := serverVersionAtLeast(tt.version, 3, 8); got != tt.want {
+			t.Errorf("serverVersionAtLeast(%q, 3, 8) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestCreateFromEditsServerPatch(t *testing.T) {
+	var input ChangeInput
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /config/server/version":
+			writeJSON(w, "3.9.1")
+		case "POST /changes/":
+			readJSON(t, r, &input)
+			writeJSON(w, ChangeInfo{ID: "p~42"})
+		case "GET /changes/p~42":
+			writeJSON(w, ChangeInfo{ID: "p~42", Number: 42})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	change, _, err := client.Changes.CreateFromEdits(context.Background(), &CreateChangeFromEditsInput{
+		ChangeInput: ChangeInput{Project: "p", Branch: "main"},
+		Message:     "Fix the build\n\nThe linker flags were wrong.\n",
+		Patch:       "--- a/x\n+++ b/x\n@@ -1 +1 @@\n-a\n+b\n",
+		Notify:      "NONE",
+	})
+	if err != nil {
+		t.Fatalf("CreateFromEdits() error = %v", err)
+	}
+	if change.Raw.Number != 42 {
+		t.Errorf("CreateFromEdits() = change %d, want 42", change.Raw.Number)
+	}
+	want := ChangeInput{
+		Project: "p",
+		Branch:  "main",
+		Subject: "Fix the build\n\nThe linker flags were wrong.\n",
+		Notify:  "NONE",
+		Patch:   &ApplyPatchInput{Patch: "--- a/x\n+++ b/x\n@@ -1 +1 @@\n-a\n+b\n"},
+	}
+	if !reflect.DeepEqual(input, want) {
+		t.Errorf("ChangeInput = %+v, want %+v", input, want)
+	}
+}
+
+func TestCreateFromEditsChangeEdit(t *testing.T) {
+	tests := []struct {
+		name      string
+		failOn    string
+		wantCalls []string
+	}{
+		{
+			name: "published",
+			wantCalls: []string{
+				"POST /changes/", "GET /changes/p~42",
+				"POST /changes/p~42/edit", "PUT /changes/p~42/edit/docs/new.md", "DELETE /changes/p~42/edit/old.md",
+				"PUT /changes/p~42/edit:message", "POST /changes/p~42/edit:publish", "GET /changes/p~42",
+			},
+		},
+		{
+			name:   "rolled back",
+			failOn: "PUT /changes/p~42/edit:message",
+			wantCalls: []string{
+				"POST /changes/", "GET /changes/p~42",
+				"POST /changes/p~42/edit", "PUT /changes/p~42/edit/docs/new.md", "DELETE /changes/p~42/edit/old.md",
+				"PUT /changes/p~42/edit:message", "DELETE /changes/p~42/edit", "DELETE /changes/p~42",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				calls   []string
+				message ChangeEditMessageInput
+				publish PublishChangeEditInput
+			)
+			client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+				call := r.Method + " " + r.URL.Path
+				calls = append(calls, call)
+				switch {
+				case call == tt.failOn:
+					http.Error(w, "conflict", http.StatusConflict)
+				case call == "POST /changes/" || call == "GET /changes/p~42":
+					_, _ = io.Copy(io.Discard, r.Body)
+					writeJSON(w, ChangeInfo{ID: "p~42", ChangeID: "I0123456789abcdef0123456789abcdef01234567"})
+				case call == "PUT /changes/p~42/edit:message":
+					readJSON(t, r, &message)
+					w.WriteHeader(http.StatusNoContent)
+				case call == "POST /changes/p~42/edit:publish":
+					readJSON(t, r, &publish)
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					w.WriteHeader(http.StatusNoContent)
+				}
+			})
+
+			_, _, err := client.Changes.CreateFromEdits(context.Background(), &CreateChangeFromEditsInput{
+				ChangeInput: ChangeInput{Project: "p", Branch: "main"},
+				Message:     "Move the docs\n\nSee the README.",
+				Edits: map[string]FileEdit{
+					"docs/new.md": {Content: []byte("# Docs\n"), RenameFrom: "docs/old.md"},
+					"old.md":      {Delete: true},
+				},
+				Notify: "OWNER",
+			})
+			if (err != nil) != (tt.failOn != "") {
+				t.Fatalf("CreateFromEdits() error = %v", err)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("calls =\n%q\nwant\n%q", calls, tt.wantCalls)
+			}
+			if tt.failOn != "" {
+				return
+			}
+			if want := "Move the docs\n\nSee the README.\n\nChange-Id: I0123456789abcdef0123456789abcdef01234567\n"; message.Message != want {
+				t.Errorf("commit message = %q, want %q", message.Message, want)
+			}
+			if publish.Notify != "OWNER" {
+				t.Errorf("publish notify = %q, want OWNER", publish.Notify)
+			}
+		})
+	}
+}
+
+func TestCreateFromEditsNothingToEdit(t *testing.T) {
+	var calls []string
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		writeJSON(w, ChangeInfo{ID: "p~42"})
+	})
+
+	_, _, err := client.Changes.CreateFromEdits(context.Background(), &CreateChangeFromEditsInput{
+		ChangeInput: ChangeInput{Project: "p", Branch: "main", Subject: "Empty"},
+		Edits:       map[string]FileEdit{"kept.md": {}},
+	})
+	if err != nil {
+		t.Fatalf("CreateFromEdits() error = %v", err)
+	}
+	if want := []string{"POST /changes/", "GET /changes/p~42"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+}
@@ -2,7 +2,9 @@ package gerrit
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 )
@@ -22,6 +24,24 @@ type EditFileInfo struct {
 	WebLinks []WebLinkInfo `json:"web_links,omitempty"`
 }
 
+// FileContentInput entity contains information for adding a file to a change edit.
+type FileContentInput struct {
+	// BinaryContent is the content of the file as a base64 encoded data URL, e.g. "data:text/plain;base64,SGVsbG8=".
+	BinaryContent string `json:"binary_content,omitempty"`
+
+	// FileMode is the mode of the file, either 100755 (executable) or 100644 (regular file).
+	// The mode of the file is kept when unset.
+	FileMode int `json:"file_mode,omitempty"`
+}
+
+// NewFileContentInput builds a FileContentInput from the raw content of a file.
+func NewFileContentInput(content []byte, fileMode int) *FileContentInput {
+	return &FileContentInput{
+		BinaryContent: "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(content),
+		FileMode:      fileMode,
+	}
+}
+
 // ChangeEditDetailOptions specifies the parameters to the ChangesService.GetChangeEditDetails.
 //
 // Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#get-edit-detail
@@ -64,6 +84,45 @@ func (c *Change) ChangeFileContentInChangeEdit(ctx context.Context, filePath, co
 	return c.gerrit.Requester.Call(ctx, "PUT", u, content, nil)
 }
 
+// ChangeBinaryFileContentInChangeEdit put the content of a file to a change edit, as a base64 encoded data URL.
+// Unlike ChangeFileContentInChangeEdit, the content is not required to be text.
+// A fileMode of 0 keeps the mode of the file.
+// As response the metadata of the file in the change edit is returned.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#put-edit-file
+func (c *Change) ChangeBinaryFileContentInChangeEdit(ctx context.Context, filePath string, content []byte, fileMode int) (*EditFileInfo, *http.Response, error) {
+	return c.PutFileContentInChangeEdit(ctx, filePath, NewFileContentInput(content, fileMode))
+}
+
+// PutFileContentInChangeEdit put the content of a file to a change edit, described by a FileContentInput entity.
+// As response the metadata of the file in the change edit is returned.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#put-edit-file
+func (c *Change) PutFileContentInChangeEdit(ctx context.Context, filePath string, input *FileContentInput) (*EditFileInfo, *http.Response, error) {
+	u := fmt.Sprintf("changes/%s/edit/%s", c.Base, url.QueryEscape(filePath))
+	resp, err := c.gerrit.Requester.Call(ctx, "PUT", u, input, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+	return c.RetrieveFileMetaFromChangeEdit(ctx, filePath)
+}
+
+// UploadFileToChangeEdit put the raw content of a file to a change edit.
+// The content is streamed to the server as application/octet-stream, so files of any size and encoding are sent unaltered.
+// A *bytes.Buffer, *bytes.Reader or *strings.Reader is sent with its length; other readers are sent once, chunked,
+// so the request cannot be replayed on a redirect.
+// As response the metadata of the file in the change edit is returned.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#put-edit-file
+func (c *Change) UploadFileToChangeEdit(ctx context.Context, filePath string, content io.Reader) (*EditFileInfo, *http.Response, error) {
+	u := fmt.Sprintf("changes/%s/edit/%s", c.Base, url.QueryEscape(filePath))
+	resp, err := c.gerrit.Requester.Call(ctx, "PUT", u, content, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+	return c.RetrieveFileMetaFromChangeEdit(ctx, filePath)
+}
+
 // RestoreChangeEdit restores file content or renames files in change edit.
 //
 // Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#post-edit
@@ -138,6 +197,36 @@ func (c *Change) RetrieveFileContentFromChangeEdit(ctx context.Context, filePath
 	return *v, resp, nil
 }
 
+// RetrieveFileContentStreamFromChangeEdit retrieves content of a file from a change edit as a stream.
+//
+// Unlike RetrieveFileContentFromChangeEdit, the content is not read into memory:
+// the returned reader decodes the base64 encoded response while it is read.
+// The caller must close it. A file deleted in the change edit reads as empty.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#get-edit-file
+func (c *Change) RetrieveFileContentStreamFromChangeEdit(ctx context.Context, filePath string) (io.ReadCloser, *http.Response, error) {
+	u := fmt.Sprintf("changes/%s/edit/%s", c.Base, url.QueryEscape(filePath))
+	req, err := c.gerrit.Requester.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := c.gerrit.Requester.client.Do(req)
+	if err != nil {
+		return nil, resp, err
+	}
+	if err := CheckResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, resp, err
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{base64.NewDecoder(base64.StdEncoding, resp.Body), resp.Body}, resp, nil
+}
+
 // RetrieveFileMetaFromChangeEdit retrieves meta data of a file from a change edit.
 // Currently only web links are returned.
 //
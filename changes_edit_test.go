@@ -0,0 +1,80 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// binaryContent is not valid UTF-8 and contains bytes a text upload would alter.
+var binaryContent = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0x00, 0xff}
+
+func TestNewFileContentInput(t *testing.T) {
+	input := NewFileContentInput(binaryContent, 100755)
+	if want := "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(binaryContent); input.BinaryContent != want {
+		t.Errorf("BinaryContent = %q, want %q", input.BinaryContent, want)
+	}
+	if input.FileMode != 100755 {
+		t.Errorf("FileMode = %d, want 100755", input.FileMode)
+	}
+}
+
+func TestUploadFileToChangeEdit(t *testing.T) {
+	var uploaded []byte
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "PUT /changes/42/edit/img%2Flogo.png":
+			if r.Header.Get("Content-Type") != "application/octet-stream" {
+				t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+			}
+			uploaded, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /changes/42/edit/img%2Flogo.png/meta":
+			writeJSON(w, EditFileInfo{WebLinks: []WebLinkInfo{{Name: "browse"}}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	})
+
+	info, _, err := NewChange(client, "42").UploadFileToChangeEdit(context.Background(), "img/logo.png", bytes.NewReader(binaryContent))
+	if err != nil {
+		t.Fatalf("UploadFileToChangeEdit() error = %v", err)
+	}
+	if !bytes.Equal(uploaded, binaryContent) {
+		t.Errorf("uploaded %q, want %q", uploaded, binaryContent)
+	}
+	if len(info.WebLinks) != 1 {
+		t.Errorf("UploadFileToChangeEdit() = %+v, want the file metadata", info)
+	}
+}
+
+func TestRetrieveFileContentStreamFromChangeEdit(t *testing.T) {
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/plain" {
+			t.Errorf("Accept = %q, want text/plain", r.Header.Get("Accept"))
+		}
+		if r.URL.EscapedPath() == "/changes/42/edit/missing" {
+			http.Error(w, "Not found", http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, base64.StdEncoding.EncodeToString(binaryContent))
+	})
+	change := NewChange(client, "42")
+
+	stream, _, err := change.RetrieveFileContentStreamFromChangeEdit(context.Background(), "img/logo.png")
+	if err != nil {
+		t.Fatalf("RetrieveFileContentStreamFromChangeEdit() error = %v", err)
+	}
+	content, err := io.ReadAll(stream)
+	stream.Close()
+	if err != nil || !bytes.Equal(content, binaryContent) {
+		t.Errorf("content = %q, %v, want %q", content, err, binaryContent)
+	}
+
+	if _, _, err := change.RetrieveFileContentStreamFromChangeEdit(context.Background(), "missing"); err == nil {
+		t.Error("RetrieveFileContentStreamFromChangeEdit() of a missing file succeeded, want an error")
+	}
+}
@@ -0,0 +1,118 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// FixPreviewInfo maps the file paths touched by a fix to the diff that applying the fix would produce.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#preview-stored-fix
+type FixPreviewInfo map[string]DiffInfo
+
+// ApplyProvidedFixInput entity contains information for applying fixes, provided in the request body, to a change edit.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#apply-provided-fix
+type ApplyProvidedFixInput struct {
+	// The fixes to be applied as a list of FixReplacementInfo entities.
+	FixReplacementInfos []FixReplacementInfo `json:"fix_replacement_infos"`
+
+	// The patch set number the fix was generated for.
+	// Gerrit rejects the fix when it was generated for another patch set than the one it is applied on.
+	OriginalPatchsetForFix int `json:"original_patchset_for_fix,omitempty"`
+}
+
+// PreviewRevisionFix gets the diffs of all files for a certain stored fix, identified by its fixID.
+// The fix must be part of a robot comment on the revision.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#preview-stored-fix
+func (c *Change) PreviewRevisionFix(ctx context.Context, revisionID, fixID string) (FixPreviewInfo, *http.Response, error) {
+	v := make(FixPreviewInfo)
+	u := fmt.Sprintf("changes/%s/revisions/%s/fixes/%s/preview", c.Base, revisionID, url.PathEscape(fixID))
+
+	resp, err := c.gerrit.Requester.Call(ctx, "GET", u, nil, &v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// ApplyRevisionFix applies a stored fix, identified by its fixID, to a change edit.
+// If no change edit exists for the change yet, it is created.
+//
+// As response an EditInfo entity is returned that describes the change edit.
+// If the fix can't be applied, e.g. because it refers to an outdated revision, the response is “409 Conflict”.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#apply-stored-fix
+func (c *Change) ApplyRevisionFix(ctx context.Context, revisionID, fixID string) (*EditInfo, *http.Response, error) {
+	v := new(EditInfo)
+	u := fmt.Sprintf("changes/%s/revisions/%s/fixes/%s/apply", c.Base, revisionID, url.PathEscape(fixID))
+
+	resp, err := c.gerrit.Requester.Call(ctx, "POST", u, nil, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// PreviewRevisionProvidedFix gets the diffs of all files for the fixes provided in the request body.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#preview-provided-fix
+func (c *Change) PreviewRevisionProvidedFix(ctx context.Context, revisionID string, input *ApplyProvidedFixInput) (FixPreviewInfo, *http.Response, error) {
+	v := make(FixPreviewInfo)
+	u := fmt.Sprintf("changes/%s/revisions/%s/fix:preview", c.Base, revisionID)
+
+	resp, err := c.gerrit.Requester.Call(ctx, "POST", u, input, &v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// ApplyRevisionProvidedFix applies the fixes provided in the request body to a change edit.
+// If no change edit exists for the change yet, it is created.
+//
+// As response an EditInfo entity is returned that describes the change edit.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#apply-provided-fix
+func (c *Change) ApplyRevisionProvidedFix(ctx context.Context, revisionID string, input *ApplyProvidedFixInput) (*EditInfo, *http.Response, error) {
+	v := new(EditInfo)
+	u := fmt.Sprintf("changes/%s/revisions/%s/fix:apply", c.Base, revisionID)
+
+	resp, err := c.gerrit.Requester.Call(ctx, "POST", u, input, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// AddRevisionRobotComments publishes robot comments, optionally carrying fix suggestions, on a revision.
+// The comments are posted through SetRevisionReview; the remaining fields of input, if given,
+// are sent along with them (e.g. a Tag, a Message or Labels).
+//
+// Every comment must name the robot that generated it and the run it belongs to.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#set-review
+func (c *Change) AddRevisionRobotComments(ctx context.Context, revisionID string, comments map[string][]RobotCommentInput, input *ReviewInput) (*ReviewResult, *http.Response, error) {
+	for path, list := range comments {
+		for _, comment := range list {
+			if comment.RobotID == "" || comment.RobotRunID == "" {
+				return nil, nil, fmt.Errorf("robot comment on %q: robot_id and robot_run_id are required", path)
+			}
+		}
+	}
+	if len(comments) == 0 {
+		return nil, nil, errors.New("no robot comments to publish")
+	}
+
+	review := ReviewInput{}
+	if input != nil {
+		review = *input
+	}
+	review.RobotComments = comments
+
+	return c.SetRevisionReview(ctx, revisionID, &review)
+}
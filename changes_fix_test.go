@@ -0,0 +1,67 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestAddRevisionRobotComments(t *testing.T) {
+	var got ReviewInput
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/changes/42/revisions/current/review" {
+			t.Errorf("request = %s %s, want POST /changes/42/revisions/current/review", r.Method, r.URL.Path)
+		}
+		readJSON(t, r, &got)
+		writeJSON(w, ReviewResult{})
+	})
+	change := NewChange(client, "42")
+
+	comment := RobotCommentInput{
+		CommentInput: CommentInput{Line: 3, Message: "unused variable"},
+		RobotID:      "vet",
+		RobotRunID:   "1",
+	}
+	comments := map[string][]RobotCommentInput{"main.go": {comment}}
+	if _, _, err := change.AddRevisionRobotComments(context.Background(), "current", comments, &ReviewInput{Tag: "autogenerated:vet"}); err != nil {
+		t.Fatalf("AddRevisionRobotComments() error = %v", err)
+	}
+	if got.Tag != "autogenerated:vet" || len(got.RobotComments["main.go"]) != 1 || got.RobotComments["main.go"][0].RobotID != "vet" {
+		t.Errorf("review = %+v, want the tag and the robot comment", got)
+	}
+
+	tests := []struct {
+		name     string
+		comments map[string][]RobotCommentInput
+		wantErr  string
+	}{
+		{"no comments", nil, "no robot comments to publish"},
+		{"no robot ID", map[string][]RobotCommentInput{"main.go": {{RobotRunID: "1"}}}, `robot comment on "main.go": robot_id and robot_run_id are required`},
+		{"no run ID", map[string][]RobotCommentInput{"main.go": {{RobotID: "vet"}}}, `robot comment on "main.go": robot_id and robot_run_id are required`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := change.AddRevisionRobotComments(context.Background(), "current", tt.comments, nil)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("AddRevisionRobotComments() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPreviewRevisionFix(t *testing.T) {
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		if want := "/changes/42/revisions/1/fixes/fix%2F1/preview"; r.URL.EscapedPath() != want {
+			t.Errorf("path = %s, want %s", r.URL.EscapedPath(), want)
+		}
+		writeJSON(w, FixPreviewInfo{"main.go": {ChangeType: "MODIFIED"}})
+	})
+
+	preview, _, err := NewChange(client, "42").PreviewRevisionFix(context.Background(), "1", "fix/1")
+	if err != nil {
+		t.Fatalf("PreviewRevisionFix() error = %v", err)
+	}
+	if preview["main.go"].ChangeType != "MODIFIED" {
+		t.Errorf("PreviewRevisionFix() = %+v, want a diff of main.go", preview)
+	}
+}
@@ -0,0 +1,152 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// AttentionInboxOptions specifies the parameters to ChangeService.GetAttentionInbox.
+type AttentionInboxOptions struct {
+	// User is the account whose attention set is queried, e.g. an email or account ID. Defaults to "self".
+	User string
+
+	// Query is added to the search, e.g. "project:myProject" or "-is:wip". Only open changes are searched.
+	Query string
+
+	// AdditionalFields are the o= options the changes are retrieved with.
+	AdditionalFields []string
+}
+
+// AttentionInboxItem is a change that awaits the attention of a user.
+type AttentionInboxItem struct {
+	Change ChangeInfo
+
+	// Attention is the attention set entry of the user, with the reason why the change needs their attention.
+	Attention AttentionSetInfo
+
+	// Waiting is how long the user has been in the attention set of the change.
+	Waiting time.Duration
+}
+
+// AttentionInbox lists the changes awaiting the attention of a user, longest waiting first.
+type AttentionInbox struct {
+	// Account is the user the inbox belongs to.
+	Account AccountInfo
+
+	Items []AttentionInboxItem
+}
+
+// ByProject groups the items of the inbox by project, keeping the longest waiting first within each project.
+func (i *AttentionInbox) ByProject() map[string][]AttentionInboxItem {
+	projects := make(map[string][]AttentionInboxItem)
+	for _, item := range i.Items {
+		projects[item.Change.Project] = append(projects[item.Change.Project], item)
+	}
+	return projects
+}
+
+// Projects returns the projects of the inbox, the one with the longest waiting change first.
+func (i *AttentionInbox) Projects() []string {
+	seen := make(map[string]bool)
+	var projects []string
+	for _, item := range i.Items {
+		if !seen[item.Change.Project] {
+			seen[item.Change.Project] = true
+			projects = append(projects, item.Change.Project)
+		}
+	}
+	return projects
+}
+
+// GetAttentionInbox lists the open changes that have a user in their attention set, longest waiting first.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/user-attention-set.html
+func (s *ChangeService) GetAttentionInbox(ctx context.Context, opt *AttentionInboxOptions) (*AttentionInbox, *http.Response, error) {
+	o := AttentionInboxOptions{}
+	if opt != nil {
+		o = *opt
+	}
+	if o.User == "" {
+		o.User = "self"
+	}
+
+	account, resp, err := s.gerrit.Accounts.Get(ctx, o.User)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	changes, resp, err := s.queryAll(ctx, attentionQuery(account.Raw.AccountID, o.Query, o.AdditionalFields))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	now := time.Now()
+	inbox := &AttentionInbox{Account: *account.Raw}
+	key := strconv.Itoa(account.Raw.AccountID)
+	for _, change := range changes {
+		attention, ok := change.AttentionSet[key]
+		if !ok {
+			continue
+		}
+		inbox.Items = append(inbox.Items, AttentionInboxItem{
+			Change:    change,
+			Attention: attention,
+			Waiting:   now.Sub(attention.LastUpdate.Time),
+		})
+	}
+	sort.SliceStable(inbox.Items, func(i, j int) bool {
+		return inbox.Items[i].Waiting > inbox.Items[j].Waiting
+	})
+
+	return inbox, resp, nil
+}
+
+// RemoveFromAllAttentionSets removes a user from the attention set of every open change they are in, e.g. while they are on vacation.
+// A failure to update one change does not prevent updating the others.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#remove-from-attention-set
+func (s *ChangeService) RemoveFromAllAttentionSets(ctx context.Context, user string, input *AttentionSetInput) ([]ChangeOperationResult, *http.Response, error) {
+	if input == nil || input.Reason == "" {
+		return nil, nil, fmt.Errorf("remove %s from attention sets: a reason is required", user)
+	}
+
+	account, resp, err := s.gerrit.Accounts.Get(ctx, user)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	changes, resp, err := s.queryAll(ctx, attentionQuery(account.Raw.AccountID, "", nil))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	accountID := strconv.Itoa(account.Raw.AccountID)
+	results := make([]ChangeOperationResult, 0, len(changes))
+	for i := range changes {
+		change := changes[i]
+		result := ChangeOperationResult{ChangeNumber: change.Number}
+		_, result.Err = NewChange(s.gerrit, change.ID).RemoveAttention(ctx, accountID, input)
+		if result.Err == nil {
+			delete(change.AttentionSet, accountID)
+			result.Change = &change
+		}
+		results = append(results, result)
+	}
+	return results, resp, nil
+}
+
+// attentionQuery builds the query for the open changes in the attention set of an account.
+func attentionQuery(accountID int, query string, fields []string) *QueryChangeOptions {
+	q := fmt.Sprintf("attention:%d is:open", accountID)
+	if query != "" {
+		q += " (" + query + ")"
+	}
+	opt := &QueryChangeOptions{}
+	opt.Query = []string{q}
+	opt.AdditionalFields = fields
+	return opt
+}
@@ -0,0 +1,143 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// inboxChanges are the open changes in the attention set of account 1000, with a change of another user's attention set.
+func inboxChanges(now time.Time) []ChangeInfo {
+	waiting := func(d time.Duration) map[string]AttentionSetInfo {
+		return map[string]AttentionSetInfo{"1000": {
+			Account:    AccountInfo{AccountID: 1000},
+			LastUpdate: Timestamp{now.Add(-d).UTC()},
+			Reason:     "Reviewer was added",
+		}}
+	}
+	return []ChangeInfo{
+		{ID: "a~1", Number: 1, Project: "a", AttentionSet: waiting(time.Hour)},
+		{ID: "b~2", Number: 2, Project: "b", AttentionSet: waiting(3 * time.Hour)},
+		{ID: "a~3", Number: 3, Project: "a", AttentionSet: waiting(2 * time.Hour)},
+		{ID: "c~4", Number: 4, Project: "c", AttentionSet: map[string]AttentionSetInfo{"2000": {}}, MoreChanges: true},
+	}
+}
+
+func TestAttentionQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "attention:1000 is:open"},
+		{"project:a OR project:b", "attention:1000 is:open (project:a OR project:b)"},
+	}
+	for _, tt := range tests {
+		opt := attentionQuery(1000, tt.query, []string{"LABELS"})
+		if !reflect.DeepEqual(opt.Query, []string{tt.want}) || !reflect.DeepEqual(opt.AdditionalFields, []string{"LABELS"}) {
+			t.Errorf("attentionQuery(%q) = %+v, want query %q", tt.query, opt, tt.want)
+		}
+	}
+}
+
+func TestGetAttentionInbox(t *testing.T) {
+	changes := inboxChanges(time.Now())
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/jane":
+			writeJSON(w, AccountInfo{AccountID: 1000, Name: "Jane"})
+		case "/changes/":
+			if q := r.URL.Query().Get("q"); q != "attention:1000 is:open (-is:wip)" {
+				t.Errorf("query = %q, want the attention set of account 1000", q)
+			}
+			// The results are paged: the second page starts after the four changes of the first one.
+			if r.URL.Query().Get("start") == "4" {
+				writeJSON(w, []ChangeInfo{})
+				return
+			}
+			writeJSON(w, changes)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	inbox, _, err := client.Changes.GetAttentionInbox(context.Background(), &AttentionInboxOptions{User: "jane", Query: "-is:wip"})
+	if err != nil {
+		t.Fatalf("GetAttentionInbox() error = %v", err)
+	}
+	if inbox.Account.AccountID != 1000 {
+		t.Errorf("Account = %+v, want account 1000", inbox.Account)
+	}
+
+	var numbers []int
+	for _, item := range inbox.Items {
+		numbers = append(numbers, item.Change.Number)
+		if item.Attention.Reason != "Reviewer was added" || item.Waiting < time.Hour {
+			t.Errorf("item %d = %+v, want the attention set entry of the user", item.Change.Number, item)
+		}
+	}
+	if want := []int{2, 3, 1}; !reflect.DeepEqual(numbers, want) {
+		t.Errorf("Items = %v, want %v, longest waiting first", numbers, want)
+	}
+	if got, want := inbox.Projects(), []string{"b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Projects() = %v, want %v", got, want)
+	}
+	byProject := inbox.ByProject()
+	if len(byProject) != 2 || len(byProject["a"]) != 2 || byProject["a"][0].Change.Number != 3 {
+		t.Errorf("ByProject() = %+v, want the changes of a longest waiting first", byProject)
+	}
+}
+
+func TestRemoveFromAllAttentionSets(t *testing.T) {
+	var removed []string
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/accounts/jane":
+			writeJSON(w, AccountInfo{AccountID: 1000})
+		case r.URL.Path == "/changes/":
+			writeJSON(w, inboxChanges(time.Now())[:3])
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/attention/1000/delete"):
+			var in AttentionSetInput
+			readJSON(t, r, &in)
+			if in.Reason != "On vacation" {
+				t.Errorf("Reason = %q, want On vacation", in.Reason)
+			}
+			if r.URL.Path == "/changes/b~2/attention/1000/delete" {
+				http.Error(w, "change is read-only", http.StatusConflict)
+				return
+			}
+			removed = append(removed, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	results, _, err := client.Changes.RemoveFromAllAttentionSets(context.Background(), "jane", &AttentionSetInput{Reason: "On vacation"})
+	if err != nil {
+		t.Fatalf("RemoveFromAllAttentionSets() error = %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("RemoveFromAllAttentionSets() = %d results, want 3", len(results))
+	}
+	for _, result := range results {
+		failed := result.ChangeNumber == 2
+		if (result.Err != nil) != failed || (result.Change == nil) != failed {
+			t.Errorf("result of change %d = %+v, want failed %v", result.ChangeNumber, result, failed)
+		}
+		if result.Change != nil && len(result.Change.AttentionSet) != 0 {
+			t.Errorf("change %d AttentionSet = %v, want the user removed", result.ChangeNumber, result.Change.AttentionSet)
+		}
+	}
+	if want := []string{"/changes/a~1/attention/1000/delete", "/changes/a~3/attention/1000/delete"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+}
+
+func TestRemoveFromAllAttentionSetsNeedsReason(t *testing.T) {
+	if _, _, err := (&ChangeService{}).RemoveFromAllAttentionSets(context.Background(), "jane", &AttentionSetInput{}); err == nil {
+		t.Error("RemoveFromAllAttentionSets() error = nil, want an error without a reason")
+	}
+}
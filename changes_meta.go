@@ -0,0 +1,243 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChangeInfoDifference entity contains the difference between two versions of a change's metadata.
+type ChangeInfoDifference struct {
+	Added   ChangeInfo `json:"added"`
+	Removed ChangeInfo `json:"removed"`
+}
+
+// MetaDiffOptions specifies the parameters to Change.GetMetaDiff.
+type MetaDiffOptions struct {
+	// Old is the SHA-1 of the meta ref state to compare against. Defaults to the parent of Meta.
+	Old string `url:"old,omitempty"`
+
+	// Meta is the SHA-1 of the meta ref state to compare, e.g. a ChangeInfo.MetaRevID. Defaults to the current state.
+	Meta string `url:"meta,omitempty"`
+
+	ChangeOptions
+}
+
+// ChangeMetaEventType is the kind of update of a ChangeMetaEvent.
+type ChangeMetaEventType string
+
+const (
+	MetaEventPatchSet    ChangeMetaEventType = "PATCH_SET"
+	MetaEventVote        ChangeMetaEventType = "VOTE"
+	MetaEventVoteRemoved ChangeMetaEventType = "VOTE_REMOVED"
+	MetaEventStatus      ChangeMetaEventType = "STATUS"
+	MetaEventReviewer    ChangeMetaEventType = "REVIEWER"
+	MetaEventAttention   ChangeMetaEventType = "ATTENTION"
+)
+
+// ChangeMetaEvent is a single update recorded in the NoteDb meta ref of a change.
+type ChangeMetaEvent struct {
+	Type ChangeMetaEventType
+
+	// Commit is the SHA-1 of the meta commit that recorded the update.
+	Commit string
+
+	// Author is the identity of the user who made the update, e.g. "Gerrit User 1000000 <1000000@uuid>",
+	// and AuthorID its account ID.
+	Author   string
+	AuthorID int
+
+	// Time is when the update was made.
+	Time time.Time
+
+	// PatchSet is the patch set the update applies to.
+	PatchSet int
+
+	// Label and Value are the label and value of VOTE and VOTE_REMOVED events.
+	Label string
+	Value int
+
+	// Account is the identity of the user a vote, reviewer or attention set update is about, and AccountID its account ID.
+	// For votes cast by the author, Account is the author.
+	Account   string
+	AccountID int
+
+	// Status is the new status of the change for STATUS events: NEW, MERGED or ABANDONED.
+	Status string
+
+	// ReviewerState is REVIEWER, CC or REMOVED for REVIEWER events.
+	ReviewerState string
+
+	// Operation is ADD or REMOVE for ATTENTION events.
+	Operation string
+
+	// Reason is the reason of an attention set update.
+	Reason string
+}
+
+// metaAttention is the JSON value of an "Attention" footer.
+type metaAttention struct {
+	PersonIdent string `json:"person_ident"`
+	Operation   string `json:"operation"`
+	Reason      string `json:"reason"`
+}
+
+var metaAccountID = regexp.MustCompile(`<(\d+)@[^>]*>`)
+
+// GetMetaDiff retrieves the difference between two states of the metadata of a change.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#get-meta-diff
+func (c *Change) GetMetaDiff(ctx context.Context, opt *MetaDiffOptions) (*ChangeInfoDifference, *http.Response, error) {
+	v := new(ChangeInfoDifference)
+	u := fmt.Sprintf("changes/%s/meta_diff", c.Base)
+
+	resp, err := c.gerrit.Requester.Call(ctx, "GET", u, opt, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// ChangeMetaRef returns the name of the NoteDb ref holding the metadata of a change, e.g. "refs/changes/47/4247/meta".
+func ChangeMetaRef(changeNumber int) string {
+	return fmt.Sprintf("refs/changes/%02d/%d/meta", changeNumber%100, changeNumber)
+}
+
+// GetChangeMetaHistory reads the whole NoteDb meta ref of a change and returns its updates as a timeline, oldest first.
+// Reading meta refs requires the "Access Database" global capability, or the change to be visible and the ref readable.
+func (gs *Gitiles) GetChangeMetaHistory(ctx context.Context, project string, changeNumber int) ([]ChangeMetaEvent, *http.Response, error) {
+	var (
+		commits []GitilesCommitInfo
+		resp    *http.Response
+		opt     GitilesCommitsOptions
+	)
+	for {
+		logs, r, err := gs.GetRefLogs(ctx, project, ChangeMetaRef(changeNumber), &opt)
+		resp = r
+		if err != nil {
+			return nil, resp, err
+		}
+		commits = append(commits, logs.Log...)
+		if logs.Next == "" {
+			break
+		}
+		opt.Start = logs.Next
+	}
+
+	var events []ChangeMetaEvent
+	// Gitiles lists the newest commit first.
+	for i := len(commits) - 1; i >= 0; i-- {
+		events = append(events, ParseChangeMetaCommit(commits[i])...)
+	}
+	return events, resp, nil
+}
+
+// ParseChangeMetaCommit parses the footers of a NoteDb meta commit into events.
+// The Patch-set, Commit, Label, Status, Reviewer, CC, Removed and Attention footers are understood; others are ignored.
+func ParseChangeMetaCommit(commit GitilesCommitInfo) []ChangeMetaEvent {
+	base := ChangeMetaEvent{
+		Commit:   commit.Commit,
+		Author:   fmt.Sprintf("%s <%s>", commit.Author.Name, commit.Author.Email),
+		AuthorID: metaIdentAccountID("<" + commit.Author.Email + ">"),
+		Time:     parseGitilesTime(commit.Author.Time),
+	}
+	footers := ParseFooters(commit.Message)
+	for _, footer := range footers {
+		if strings.EqualFold(footer.Key, "Patch-set") {
+			n, _ := strconv.Atoi(strings.Fields(footer.Value + " ")[0])
+			base.PatchSet = n
+		}
+	}
+
+	var events []ChangeMetaEvent
+	for _, footer := range footers {
+		event := base
+		switch strings.ToLower(footer.Key) {
+		case "commit":
+			event.Type = MetaEventPatchSet
+		case "label":
+			if !parseMetaLabel(footer.Value, &event) {
+				continue
+			}
+		case "status":
+			event.Type = MetaEventStatus
+			event.Status = strings.ToUpper(footer.Value)
+		case "reviewer", "cc", "removed":
+			event.Type = MetaEventReviewer
+			event.ReviewerState = strings.ToUpper(footer.Key)
+			event.Account = footer.Value
+			event.AccountID = metaIdentAccountID(footer.Value)
+		case "attention":
+			var attention metaAttention
+			if err := json.Unmarshal([]byte(footer.Value), &attention); err != nil {
+				continue
+			}
+			event.Type = MetaEventAttention
+			event.Operation = attention.Operation
+			event.Reason = attention.Reason
+			event.Account = attention.PersonIdent
+			event.AccountID = metaIdentAccountID(attention.PersonIdent)
+		default:
+			continue
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// parseMetaLabel parses a "Label" footer value like "Code-Review=-2", "Code-Review=+1, <uuid> Gerrit User 1 <1@uuid>"
+// or "-Code-Review Gerrit User 1 <1@uuid>".
+func parseMetaLabel(value string, event *ChangeMetaEvent) bool {
+	spec, ident, _ := strings.Cut(value, " ")
+	spec = strings.TrimSuffix(spec, ",")
+	if strings.HasPrefix(value, spec+", ") {
+		// Skip the UUID of the vote.
+		_, ident, _ = strings.Cut(strings.TrimPrefix(value, spec+", "), " ")
+	}
+
+	if strings.HasPrefix(spec, "-") {
+		event.Type = MetaEventVoteRemoved
+		event.Label = spec[1:]
+	} else {
+		name, v, ok := strings.Cut(spec, "=")
+		if !ok {
+			return false
+		}
+		n, err := ParseLabelValue(v)
+		if err != nil {
+			return false
+		}
+		event.Type = MetaEventVote
+		event.Label, event.Value = name, n
+	}
+
+	event.Account, event.AccountID = event.Author, event.AuthorID
+	if ident = strings.TrimSpace(ident); ident != "" {
+		event.Account, event.AccountID = ident, metaIdentAccountID(ident)
+	}
+	return event.Label != ""
+}
+
+// metaIdentAccountID extracts the account ID of a NoteDb identity like "Gerrit User 1000000 <1000000@uuid>".
+func metaIdentAccountID(ident string) int {
+	m := metaAccountID.FindStringSubmatch(ident)
+	if m == nil {
+		return 0
+	}
+	id, _ := strconv.Atoi(m[1])
+	return id
+}
+
+// parseGitilesTime parses the time of a GitilesPersonInfo, e.g. "Mon Jan 02 15:04:05 2006 -0700".
+func parseGitilesTime(s string) time.Time {
+	t, err := time.Parse("Mon Jan 02 15:04:05 2006 -0700", s)
+	if err != nil {
+		t, _ = time.Parse("Mon Jan _2 15:04:05 2006 -0700", s)
+	}
+	return t
+}
@@ -0,0 +1,226 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChangeMetaRef(t *testing.T) {
+	tests := map[int]string{
+		4247: "refs/changes/47/4247/meta",
+		5:    "refs/changes/05/5/meta",
+		100:  "refs/changes/00/100/meta",
+	}
+	for number, want := range tests {
+		if got := ChangeMetaRef(number); got != want {
+			t.Errorf("ChangeMetaRef(%d) = %q, want %q", number, got, want)
+		}
+	}
+}
+
+func TestParseMetaLabel(t *testing.T) {
+	author := ChangeMetaEvent{Author: "Gerrit User 1000000 <1000000@uuid>", AuthorID: 1000000}
+	tests := []struct {
+		value string
+		want  ChangeMetaEvent
+		ok    bool
+	}{
+		{
+			value: "Code-Review=-2",
+			want:  ChangeMetaEvent{Type: MetaEventVote, Label: "Code-Review", Value: -2, Account: author.Author, AccountID: 1000000},
+			ok:    true,
+		},
+		{
+			value: "Verified=+1 Gerrit User 1000001 <1000001@uuid>",
+			want:  ChangeMetaEvent{Type: MetaEventVote, Label: "Verified", Value: 1, Account: "Gerrit User 1000001 <1000001@uuid>", AccountID: 1000001},
+			ok:    true,
+		},
+		{
+			value: "Code-Review=+1, 3b6a4c8e Gerrit User 1000002 <1000002@uuid>",
+			want:  ChangeMetaEvent{Type: MetaEventVote, Label: "Code-Review", Value: 1, Account: "Gerrit User 1000002 <1000002@uuid>", AccountID: 1000002},
+			ok:    true,
+		},
+		{
+			value: "Code-Review=0, 3b6a4c8e",
+			want:  ChangeMetaEvent{Type: MetaEventVote, Label: "Code-Review", Account: author.Author, AccountID: 1000000},
+			ok:    true,
+		},
+		{
+			value: "-Verified Gerrit User 1000001 <1000001@uuid>",
+			want:  ChangeMetaEvent{Type: MetaEventVoteRemoved, Label: "Verified", Account: "Gerrit User 1000001 <1000001@uuid>", AccountID: 1000001},
+			ok:    true,
+		},
+		{value: "Code-Review"},
+		{value: "Code-Review=high"},
+		{value: "=+1"},
+		{value: "-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			event := author
+			ok := parseMetaLabel(tt.value, &event)
+			if ok != tt.ok {
+				t.Fatalf("parseMetaLabel() = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			tt.want.Author, tt.want.AuthorID = author.Author, author.AuthorID
+			if !reflect.DeepEqual(event, tt.want) {
+				t.Errorf("parseMetaLabel() event = %+v, want %+v", event, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetaIdentAccountID(t *testing.T) {
+	tests := map[string]int{
+		"Gerrit User 1000000 <1000000@0d9e4a8c-6a1e>": 1000000,
+		"<42@uuid>":               42,
+		"Jane Doe <jane@example>": 0,
+		"":                        0,
+	}
+	for ident, want := range tests {
+		if got := metaIdentAccountID(ident); got != want {
+			t.Errorf("metaIdentAccountID(%q) = %d, want %d", ident, got, want)
+		}
+	}
+}
+
+func TestParseGitilesTime(t *testing.T) {
+	tests := map[string]time.Time{
+		"Tue Nov 14 22:13:20 2023 +0000": time.Unix(1700000000, 0),
+		"Sat Nov  4 08:26:40 2023 +0100": time.Unix(1699082800, 0),
+		"Sat Nov 4 08:26:40 2023 +0100":  time.Unix(1699082800, 0),
+		"2023-11-14T22:13:20Z":           {},
+		"":                               {},
+	}
+	for s, want := range tests {
+		if got := parseGitilesTime(s); !got.Equal(want) {
+			t.Errorf("parseGitilesTime(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestParseChangeMetaCommit(t *testing.T) {
+	commit := GitilesCommitInfo{
+		Commit: "8e0c1a6f",
+		Author: GitilesPersonInfo{Name: "Gerrit User 1000000", Email: "1000000@uuid", Time: "Tue Nov 14 22:13:20 2023 +0000"},
+		Message: "Update patch set 2\n\n" +
+			"Patch-set: 2 (draft)\n" +
+			"Commit: 6cb80b2c1a4b44bfdc5568ce52b0dd7781aeca97\n" +
+			"Label: Code-Review=+2\n" +
+			"Label: -Verified Gerrit User 1000001 <1000001@uuid>\n" +
+			"CC: Gerrit User 1000002 <1000002@uuid>\n" +
+			`Attention: {"person_ident":"Gerrit User 1000003 <1000003@uuid>","operation":"ADD","reason":"Reviewer was added"}` + "\n" +
+			"Attention: not json\n" +
+			"Status: merged\n" +
+			"Submitted-with: OK\n",
+	}
+	base := ChangeMetaEvent{
+		Commit:   "8e0c1a6f",
+		Author:   "Gerrit User 1000000 <1000000@uuid>",
+		AuthorID: 1000000,
+		Time:     time.Unix(1700000000, 0).UTC(),
+		PatchSet: 2,
+	}
+	event := func(update func(*ChangeMetaEvent)) ChangeMetaEvent {
+		e := base
+		update(&e)
+		return e
+	}
+	want := []ChangeMetaEvent{
+		event(func(e *ChangeMetaEvent) { e.Type = MetaEventPatchSet }),
+		event(func(e *ChangeMetaEvent) {
+			e.Type, e.Label, e.Value, e.Account, e.AccountID = MetaEventVote, "Code-Review", 2, base.Author, base.AuthorID
+		}),
+		event(func(e *ChangeMetaEvent) {
+			e.Type, e.Label, e.Account, e.AccountID = MetaEventVoteRemoved, "Verified", "Gerrit User 1000001 <1000001@uuid>", 1000001
+		}),
+		event(func(e *ChangeMetaEvent) {
+			e.Type, e.ReviewerState, e.Account, e.AccountID = MetaEventReviewer, "CC", "Gerrit User 1000002 <1000002@uuid>", 1000002
+		}),
+		event(func(e *ChangeMetaEvent) {
+			e.Type, e.Operation, e.Reason = MetaEventAttention, "ADD", "Reviewer was added"
+			e.Account, e.AccountID = "Gerrit User 1000003 <1000003@uuid>", 1000003
+		}),
+		event(func(e *ChangeMetaEvent) { e.Type, e.Status = MetaEventStatus, "MERGED" }),
+	}
+
+	got := ParseChangeMetaCommit(commit)
+	if len(got) != len(want) {
+		t.Fatalf("ParseChangeMetaCommit() = %d events, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !got[i].Time.Equal(want[i].Time) {
+			t.Errorf("event %d Time = %v, want %v", i, got[i].Time, want[i].Time)
+		}
+		got[i].Time, want[i].Time = time.Time{}, time.Time{}
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("event %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestGetChangeMetaHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/myProject/+log/refs/changes/47/4247/meta/" || r.URL.Query().Get("format") != "JSON" {
+			t.Errorf("request = %s, want the log of the meta ref", r.URL)
+		}
+		// Gitiles pages the log newest first.
+		switch r.URL.Query().Get("s") {
+		case "":
+			writeJSON(w, GitilesLogs{
+				Log: []GitilesCommitInfo{
+					{Commit: "c3", Message: "Update\n\nPatch-set: 2\nStatus: merged\n"},
+					{Commit: "c2", Message: "Update\n\nPatch-set: 2\nCommit: abc\n"},
+				},
+				Next: "c1",
+			})
+		case "c1":
+			writeJSON(w, GitilesLogs{Log: []GitilesCommitInfo{{Commit: "c1", Message: "Create change\n\nPatch-set: 1\nCommit: def\n"}}})
+		default:
+			t.Errorf("unexpected page start %q", r.URL.Query().Get("s"))
+		}
+	}))
+	defer server.Close()
+
+	gitiles, err := NewGitilesClient(server.URL, server.Client())
+	if err != nil {
+		t.Fatalf("NewGitilesClient() error = %v", err)
+	}
+	events, _, err := gitiles.GetChangeMetaHistory(context.Background(), "myProject", 4247)
+	if err != nil {
+		t.Fatalf("GetChangeMetaHistory() error = %v", err)
+	}
+
+	var got []string
+	for _, e := range events {
+		got = append(got, e.Commit+" "+string(e.Type))
+	}
+	want := []string{"c1 PATCH_SET", "c2 PATCH_SET", "c3 STATUS"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetChangeMetaHistory() = %v, want %v", got, want)
+	}
+}
+
+func TestGetMetaDiff(t *testing.T) {
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/changes/4247/meta_diff" || r.URL.Query().Get("old") != "a1" || r.URL.Query().Get("meta") != "b2" {
+			t.Errorf("request = %s, want the meta diff between a1 and b2", r.URL)
+		}
+		writeJSON(w, ChangeInfoDifference{Added: ChangeInfo{Topic: "new"}, Removed: ChangeInfo{Topic: "old"}})
+	})
+
+	diff, _, err := NewChange(client, "4247").GetMetaDiff(context.Background(), &MetaDiffOptions{Old: "a1", Meta: "b2"})
+	if err != nil {
+		t.Fatalf("GetMetaDiff() error = %v", err)
+	}
+	if diff.Added.Topic != "new" || diff.Removed.Topic != "old" {
+		t.Errorf("GetMetaDiff() = %+v, want the topic change", diff)
+	}
+}
@@ -0,0 +1,186 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ChangeRef identifies a change, and optionally a patch set and a file of it.
+type ChangeRef struct {
+	// Host is the host of the review URL the reference was parsed from, if any.
+	Host string
+
+	Project string
+	Branch  string
+
+	// ChangeID is the Change-Id of the change, e.g. "I8473b95934b5732ac55d26311a706c9c2bde9940".
+	ChangeID string
+
+	// Number is the legacy numeric ID of the change.
+	Number int
+
+	// PatchSet is the patch set number, 0 when the reference doesn't name one.
+	PatchSet int
+
+	// Path is the file the reference points to, if any.
+	Path string
+}
+
+// ParseChangeRef parses every form a change is commonly referred to by:
+//
+//   - a change number: "4247"
+//   - a project and change number: "myProject~4247"
+//   - a project, branch and Change-Id: "myProject~master~I8473b95934b5732ac55d26311a706c9c2bde9940"
+//   - a Change-Id: "I8473b95934b5732ac55d26311a706c9c2bde9940"
+//   - a change number and patch set: "4247/2" or "4247,2"
+//   - a review URL: "https://review.example.com/c/myProject/+/4247/2/path/to/file.go",
+//     including the old "https://review.example.com/#/c/4247/2" and short "https://review.example.com/4247" forms.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#change-id
+func ParseChangeRef(s string) (*ChangeRef, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("empty change reference")
+	}
+
+	if strings.Contains(s, "://") {
+		return parseChangeURL(s)
+	}
+
+	if parts := strings.Split(s, "~"); len(parts) > 1 {
+		project, err := url.QueryUnescape(parts[0])
+		if err != nil || project == "" {
+			return nil, fmt.Errorf("invalid project in change reference %q", s)
+		}
+		switch len(parts) {
+		case 2:
+			number, err := strconv.Atoi(parts[1])
+			if err != nil || number <= 0 {
+				return nil, fmt.Errorf("invalid change number in change reference %q", s)
+			}
+			return &ChangeRef{Project: project, Number: number}, nil
+		case 3:
+			branch, err := url.QueryUnescape(parts[1])
+			if err != nil || branch == "" || !changeIDLine.MatchString(parts[2]) {
+				return nil, fmt.Errorf("invalid change reference %q, want project~branch~Change-Id", s)
+			}
+			return &ChangeRef{Project: project, Branch: strings.TrimPrefix(branch, "refs/heads/"), ChangeID: parts[2]}, nil
+		}
+		return nil, fmt.Errorf("invalid change reference %q", s)
+	}
+
+	if changeIDLine.MatchString(s) {
+		return &ChangeRef{ChangeID: s}, nil
+	}
+
+	ref := &ChangeRef{}
+	number, patchSet, _ := strings.Cut(strings.Replace(s, ",", "/", 1), "/")
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		return nil, fmt.Errorf("unrecognized change reference %q", s)
+	}
+	ref.Number = n
+	if patchSet != "" {
+		if ref.PatchSet, err = parsePatchSet(patchSet); err != nil {
+			return nil, fmt.Errorf("invalid patch set in change reference %q", s)
+		}
+	}
+	return ref, nil
+}
+
+// parseChangeURL parses a review URL as shown in the address bar of the Gerrit web UI.
+func parseChangeURL(s string) (*ChangeRef, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	ref := &ChangeRef{Host: u.Host}
+
+	path := u.Path
+	if strings.HasPrefix(u.Fragment, "/") {
+		// Old web UI: https://host/#/c/4247/2
+		path = u.Fragment
+	}
+
+	var rest string
+	if i := strings.Index(path, "/c/"); i >= 0 {
+		rest = path[i+len("/c/"):]
+		if project, after, ok := strings.Cut(rest, "/+/"); ok {
+			ref.Project = project
+			rest = after
+		}
+	} else {
+		// Short link: https://host/4247
+		rest = strings.TrimPrefix(path, "/")
+	}
+
+	segments := strings.SplitN(strings.TrimSuffix(rest, "/"), "/", 3)
+	if ref.Number, err = strconv.Atoi(segments[0]); err != nil || ref.Number <= 0 {
+		return nil, fmt.Errorf("no change number in review URL %q", s)
+	}
+	if len(segments) > 1 && segments[1] != "" {
+		if ref.PatchSet, err = parsePatchSet(segments[1]); err != nil {
+			return nil, fmt.Errorf("invalid patch set in review URL %q", s)
+		}
+	}
+	if len(segments) > 2 {
+		ref.Path = strings.TrimSuffix(segments[2], ",edit")
+	}
+	return ref, nil
+}
+
+// parsePatchSet parses the patch set part of a reference. Of a range like "1..3" the right side is used.
+func parsePatchSet(s string) (int, error) {
+	if _, right, ok := strings.Cut(s, ".."); ok {
+		s = right
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid patch set %q", s)
+	}
+	return n, nil
+}
+
+// String returns the canonical change ID of the reference, as accepted by the REST API:
+// "project~number" when both are known, otherwise the change number, the triplet or the Change-Id.
+func (r ChangeRef) String() string {
+	switch {
+	case r.Number != 0 && r.Project != "":
+		return fmt.Sprintf("%s~%d", url.QueryEscape(r.Project), r.Number)
+	case r.Number != 0:
+		return strconv.Itoa(r.Number)
+	case r.Project != "" && r.Branch != "" && r.ChangeID != "":
+		return fmt.Sprintf("%s~%s~%s", url.QueryEscape(r.Project), url.QueryEscape(r.Branch), r.ChangeID)
+	}
+	return r.ChangeID
+}
+
+// RevisionID returns the revision ID of the patch set of the reference, or "current" when it doesn't name one.
+// It can be passed to the revision methods of Change.
+func (r ChangeRef) RevisionID() string {
+	if r.PatchSet == 0 {
+		return "current"
+	}
+	return strconv.Itoa(r.PatchSet)
+}
+
+// NormalizeChangeID converts any change reference ParseChangeRef understands to the canonical change ID.
+// Strings that are not recognized are returned unchanged.
+func NormalizeChangeID(changeID string) string {
+	ref, err := ParseChangeRef(changeID)
+	if err != nil {
+		return changeID
+	}
+	return ref.String()
+}
+
+// GetRef retrieves the change a ChangeRef refers to.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#get-change
+func (s *ChangeService) GetRef(ctx context.Context, ref *ChangeRef, AdditionalFields ...string) (*Change, *http.Response, error) {
+	return s.Get(ctx, ref.String(), AdditionalFields...)
+}
@@ -0,0 +1,94 @@
+package gerrit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChangeRef(t *testing.T) {
+	const id = "I8473b95934b5732ac55d26311a706c9c2bde9940"
+	tests := []struct {
+		in         string
+		want       ChangeRef
+		wantString string
+		revisionID string
+	}{
+		{"4247", ChangeRef{Number: 4247}, "4247", "current"},
+		{" 4247/2 ", ChangeRef{Number: 4247, PatchSet: 2}, "4247", "2"},
+		{"4247,3", ChangeRef{Number: 4247, PatchSet: 3}, "4247", "3"},
+		{"myProject~4247", ChangeRef{Project: "myProject", Number: 4247}, "myProject~4247", "current"},
+		{"parent%2Fchild~4247", ChangeRef{Project: "parent/child", Number: 4247}, "parent%2Fchild~4247", "current"},
+		{"myProject~refs%2Fheads%2Fmaster~" + id, ChangeRef{Project: "myProject", Branch: "master", ChangeID: id}, "myProject~master~" + id, "current"},
+		{id, ChangeRef{ChangeID: id}, id, "current"},
+		{
+			"https://review.example.com/c/parent/child/+/4247/2/path/to/file.go",
+			ChangeRef{Host: "review.example.com", Project: "parent/child", Number: 4247, PatchSet: 2, Path: "path/to/file.go"},
+			"parent%2Fchild~4247", "2",
+		},
+		{
+			"https://review.example.com/c/myProject/+/4247/1..3/",
+			ChangeRef{Host: "review.example.com", Project: "myProject", Number: 4247, PatchSet: 3},
+			"myProject~4247", "3",
+		},
+		{
+			"https://review.example.com/c/myProject/+/4247/2/README.md,edit",
+			ChangeRef{Host: "review.example.com", Project: "myProject", Number: 4247, PatchSet: 2, Path: "README.md"},
+			"myProject~4247", "2",
+		},
+		{"https://review.example.com/#/c/4247/2", ChangeRef{Host: "review.example.com", Number: 4247, PatchSet: 2}, "4247", "2"},
+		{"https://review.example.com/4247", ChangeRef{Host: "review.example.com", Number: 4247}, "4247", "current"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			ref, err := ParseChangeRef(tt.in)
+			if err != nil {
+				t.Fatalf("ParseChangeRef() error = %v", err)
+			}
+			if !reflect.DeepEqual(*ref, tt.want) {
+				t.Errorf("ParseChangeRef() = %+v, want %+v", *ref, tt.want)
+			}
+			if got := ref.String(); got != tt.wantString {
+				t.Errorf("String() = %q, want %q", got, tt.wantString)
+			}
+			if got := ref.RevisionID(); got != tt.revisionID {
+				t.Errorf("RevisionID() = %q, want %q", got, tt.revisionID)
+			}
+		})
+	}
+}
+
+func TestParseChangeRefErrors(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"abc",
+		"0",
+		"-1",
+		"4247/0",
+		"4247/x",
+		"~4247",
+		"myProject~abc",
+		"myProject~master~I0123",
+		"a~b~c~d",
+		"https://review.example.com/c/myProject/+/abc",
+		"https://review.example.com/dashboard/self",
+	} {
+		if ref, err := ParseChangeRef(in); err == nil {
+			t.Errorf("ParseChangeRef(%q) = %+v, want an error", in, ref)
+		}
+	}
+}
+
+func TestNormalizeChangeID(t *testing.T) {
+	tests := map[string]string{
+		"https://review.example.com/c/myProject/+/4247": "myProject~4247",
+		"4247/2":             "4247",
+		"myProject~4247":     "myProject~4247",
+		"not a change":       "not a change",
+		"myProject~master~x": "myProject~master~x",
+	}
+	for in, want := range tests {
+		if got := NormalizeChangeID(in); got != want {
+			t.Errorf("NormalizeChangeID(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -2,10 +2,19 @@ package gerrit
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 )
 
+// Reviewer states of ReviewerInput.State.
+const (
+	ReviewerStateReviewer = "REVIEWER"
+	ReviewerStateCC       = "CC"
+	ReviewerStateRemoved  = "REMOVED"
+)
+
 // ReviewerInfo entity contains information about a reviewer and its votes on a change.
 type ReviewerInfo struct {
 	AccountInfo
@@ -32,6 +41,30 @@ type ReviewerResult struct {
 	Confirm   bool           `json:"confirm,omitempty"`
 }
 
+// ReviewersUpdateInput describes a batch of reviewer updates for Change.UpdateReviewers.
+// Reviewers and CCs are accounts or groups; adding an existing reviewer as CC, or the other way around, moves them.
+type ReviewersUpdateInput struct {
+	Reviewers []string
+	CCs       []string
+	Remove    []string
+
+	Notify        string
+	NotifyDetails map[RecipientType]NotifyInfo
+
+	// ConfirmGroup is asked whether to add a group that is so large that Gerrit requires a confirmation.
+	// Such groups are left out when it is nil or returns false.
+	ConfirmGroup func(group string) bool
+}
+
+// ReviewersUpdateResult describes the outcome of Change.UpdateReviewers.
+type ReviewersUpdateResult struct {
+	// Results has one entry per requested update, in the order of Reviewers, CCs and Remove.
+	Results []ReviewerResult
+
+	// Unconfirmed are the groups that were left out because their addition was not confirmed.
+	Unconfirmed []string
+}
+
 // DeleteVoteInput entity contains options for the deletion of a vote.
 //
 // Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#delete-vote-input
@@ -134,4 +167,119 @@ func (c *Change) ListVotes(ctx context.Context, accountID string) (map[string]in
 func (c *Change) DeleteVote(ctx context.Context, accountID string, label string) (*http.Response, error) {
 	u := fmt.Sprintf("changes/%s/reviewers/%s/votes/%s'", c.Base, accountID, label)
 	return c.gerrit.Requester.Call(ctx, "DELETE", u, nil, nil)
-}
\ No newline at end of file
+}
+
+// UpdateReviewers adds, moves and removes many reviewers and CCs of a change in a single review.
+//
+// Gerrit applies the updates atomically: when one of them fails, none is applied and the error of each one
+// is reported in the results. When groups need a confirmation because of their size, input.ConfirmGroup decides
+// whether they are added, and the updates are sent again without the unconfirmed groups.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#set-review
+func (c *Change) UpdateReviewers(ctx context.Context, input *ReviewersUpdateInput) (*ReviewersUpdateResult, *http.Response, error) {
+	var reviewers []ReviewerInput
+	for _, r := range input.Reviewers {
+		reviewers = append(reviewers, ReviewerInput{Reviewer: r, State: ReviewerStateReviewer})
+	}
+	for _, r := range input.CCs {
+		reviewers = append(reviewers, ReviewerInput{Reviewer: r, State: ReviewerStateCC})
+	}
+	for _, r := range input.Remove {
+		reviewers = append(reviewers, ReviewerInput{Reviewer: r, State: ReviewerStateRemoved})
+	}
+	if len(reviewers) == 0 {
+		return nil, nil, errors.New("update reviewers: no reviewers given")
+	}
+
+	order := make([]string, 0, len(reviewers))
+	for _, r := range reviewers {
+		order = append(order, r.Reviewer)
+	}
+
+	results := make(map[string]ReviewerResult, len(reviewers))
+	result := &ReviewersUpdateResult{}
+	for {
+		review, resp, err := c.SetRevisionReview(ctx, "current", &ReviewInput{
+			Reviewers:     reviewers,
+			Notify:        input.Notify,
+			NotifyDetails: input.NotifyDetails,
+		})
+		if err != nil {
+			if review = reviewResultFromError(err); review == nil {
+				return nil, resp, err
+			}
+		}
+
+		failed, confirm := false, false
+		var pending []ReviewerInput
+		for _, in := range reviewers {
+			r := review.Reviewers[in.Reviewer]
+			r.Input = in.Reviewer
+			results[in.Reviewer] = r
+
+			switch {
+			case r.Confirm && !in.Confirmed:
+				confirm = true
+				if input.ConfirmGroup == nil || !input.ConfirmGroup(in.Reviewer) {
+					result.Unconfirmed = append(result.Unconfirmed, in.Reviewer)
+					continue
+				}
+				in.Confirmed = true
+			case r.Error != "":
+				failed = true
+			}
+			pending = append(pending, in)
+		}
+
+		if err == nil || failed || !confirm || len(pending) == 0 {
+			for _, r := range order {
+				result.Results = append(result.Results, results[r])
+			}
+			if !failed && confirm {
+				// Only unconfirmed groups were requested, there is nothing left to apply.
+				err = nil
+			}
+			return result, resp, err
+		}
+		reviewers = pending
+	}
+}
+
+// MoveReviewerToCC turns a reviewer of a change into a CC, removing them from the reviewers.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#set-review
+func (c *Change) MoveReviewerToCC(ctx context.Context, accountID string) (*ReviewerResult, *http.Response, error) {
+	return c.setReviewerState(ctx, accountID, ReviewerStateCC)
+}
+
+// MoveCCToReviewer turns a CC of a change into a reviewer.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#set-review
+func (c *Change) MoveCCToReviewer(ctx context.Context, accountID string) (*ReviewerResult, *http.Response, error) {
+	return c.setReviewerState(ctx, accountID, ReviewerStateReviewer)
+}
+
+func (c *Change) setReviewerState(ctx context.Context, accountID, state string) (*ReviewerResult, *http.Response, error) {
+	review, resp, err := c.SetRevisionReview(ctx, "current", &ReviewInput{
+		Reviewers: []ReviewerInput{{Reviewer: accountID, State: state}},
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	result := review.Reviewers[accountID]
+	result.Input = accountID
+	return &result, resp, nil
+}
+
+// reviewResultFromError recovers the ReviewResult Gerrit sends along with a "400 Bad Request" for failed reviewer updates.
+func reviewResultFromError(err error) *ReviewResult {
+	var e *ErrorResponse
+	if !errors.As(err, &e) || e.Response.StatusCode != http.StatusBadRequest {
+		return nil
+	}
+	review := new(ReviewResult)
+	if json.Unmarshal(RemoveMagicPrefixLine([]byte(e.Message)), review) != nil || len(review.Reviewers) == 0 {
+		return nil
+	}
+	return review
+}
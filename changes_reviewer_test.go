@@ -0,0 +1,208 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// reviewerServer answers reviewer updates like Gerrit: the group "big" needs a confirmation, "nobody" does not
+// exist, and a review with any failing reviewer is rejected with "400 Bad Request" and the per reviewer results.
+func reviewerServer(t *testing.T, requests *[][]ReviewerInput) *Gerrit {
+	return newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method+" "+r.URL.Path != "POST /changes/42/revisions/current/review" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+		var in ReviewInput
+		readJSON(t, r, &in)
+		*requests = append(*requests, in.Reviewers)
+
+		review := ReviewResult{Reviewers: map[string]ReviewerResult{}}
+		failed := false
+		for _, reviewer := range in.Reviewers {
+			result := ReviewerResult{Input: reviewer.Reviewer}
+			switch {
+			case reviewer.Reviewer == "big" && !reviewer.Confirmed:
+				result.Confirm = true
+				result.Error = "The group big has 500 members. Do you want to add them all as reviewers?"
+			case reviewer.Reviewer == "nobody":
+				result.Error = "Account 'nobody' not found"
+			case reviewer.State == ReviewerStateCC:
+				result.CCS = []AccountInfo{{Name: reviewer.Reviewer}}
+			case reviewer.State == ReviewerStateRemoved:
+				result.Removed = []AccountInfo{{Name: reviewer.Reviewer}}
+			default:
+				result.Reviewers = []ReviewerInfo{{AccountInfo: AccountInfo{Name: reviewer.Reviewer}}}
+			}
+			failed = failed || result.Error != ""
+			review.Reviewers[reviewer.Reviewer] = result
+		}
+		if failed {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		writeJSON(w, review)
+	})
+}
+
+func TestUpdateReviewers(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       ReviewersUpdateInput
+		wantStates  [][]string
+		wantResults []string
+		unconfirmed []string
+		wantErr     bool
+	}{
+		{
+			name:        "reviewers, CCs and removals",
+			input:       ReviewersUpdateInput{Reviewers: []string{"jane"}, CCs: []string{"john"}, Remove: []string{"joe"}},
+			wantStates:  [][]string{{"jane REVIEWER", "john CC", "joe REMOVED"}},
+			wantResults: []string{"jane reviewer", "john cc", "joe removed"},
+		},
+		{
+			name:        "failing reviewer",
+			input:       ReviewersUpdateInput{Reviewers: []string{"jane", "nobody"}},
+			wantStates:  [][]string{{"jane REVIEWER", "nobody REVIEWER"}},
+			wantResults: []string{"jane reviewer", "nobody error"},
+			wantErr:     true,
+		},
+		{
+			name: "confirmed group",
+			input: ReviewersUpdateInput{
+				Reviewers:    []string{"big", "jane"},
+				ConfirmGroup: func(group string) bool { return group == "big" },
+			},
+			wantStates:  [][]string{{"big REVIEWER", "jane REVIEWER"}, {"big REVIEWER confirmed", "jane REVIEWER"}},
+			wantResults: []string{"big reviewer", "jane reviewer"},
+		},
+		{
+			name:        "unconfirmed group",
+			input:       ReviewersUpdateInput{Reviewers: []string{"big"}, CCs: []string{"john"}},
+			wantStates:  [][]string{{"big REVIEWER", "john CC"}, {"john CC"}},
+			wantResults: []string{"big confirm", "john cc"},
+			unconfirmed: []string{"big"},
+		},
+		{
+			name:        "only unconfirmed groups",
+			input:       ReviewersUpdateInput{CCs: []string{"big"}, ConfirmGroup: func(string) bool { return false }},
+			wantStates:  [][]string{{"big CC"}},
+			wantResults: []string{"big confirm"},
+			unconfirmed: []string{"big"},
+		},
+		{
+			name:        "unconfirmed group and failing reviewer",
+			input:       ReviewersUpdateInput{Reviewers: []string{"big", "nobody"}},
+			wantStates:  [][]string{{"big REVIEWER", "nobody REVIEWER"}},
+			wantResults: []string{"big confirm", "nobody error"},
+			unconfirmed: []string{"big"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests [][]ReviewerInput
+			client := reviewerServer(t, &requests)
+
+			result, _, err := NewChange(client, "42").UpdateReviewers(context.Background(), &tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UpdateReviewers() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			var states [][]string
+			for _, request := range requests {
+				var s []string
+				for _, r := range request {
+					state := r.Reviewer + " " + r.State
+					if r.Confirmed {
+						state += " confirmed"
+					}
+					s = append(s, state)
+				}
+				states = append(states, s)
+			}
+			if !reflect.DeepEqual(states, tt.wantStates) {
+				t.Errorf("requests = %v, want %v", states, tt.wantStates)
+			}
+
+			var results []string
+			for _, r := range result.Results {
+				results = append(results, r.Input+" "+reviewerOutcome(r))
+			}
+			if !reflect.DeepEqual(results, tt.wantResults) {
+				t.Errorf("Results = %v, want %v", results, tt.wantResults)
+			}
+			if !reflect.DeepEqual(result.Unconfirmed, tt.unconfirmed) {
+				t.Errorf("Unconfirmed = %v, want %v", result.Unconfirmed, tt.unconfirmed)
+			}
+		})
+	}
+}
+
+// reviewerOutcome summarizes a ReviewerResult for comparisons.
+func reviewerOutcome(r ReviewerResult) string {
+	switch {
+	case r.Confirm:
+		return "confirm"
+	case r.Error != "":
+		return "error"
+	case len(r.Reviewers) > 0:
+		return "reviewer"
+	case len(r.CCS) > 0:
+		return "cc"
+	case len(r.Removed) > 0:
+		return "removed"
+	}
+	return "none"
+}
+
+func TestUpdateReviewersNoReviewers(t *testing.T) {
+	if _, _, err := NewChange(&Gerrit{}, "42").UpdateReviewers(context.Background(), &ReviewersUpdateInput{}); err == nil {
+		t.Error("UpdateReviewers() error = nil, want an error")
+	}
+}
+
+func TestMoveReviewerToCC(t *testing.T) {
+	var requests [][]ReviewerInput
+	client := reviewerServer(t, &requests)
+
+	result, _, err := NewChange(client, "42").MoveReviewerToCC(context.Background(), "jane")
+	if err != nil {
+		t.Fatalf("MoveReviewerToCC() error = %v", err)
+	}
+	if result.Input != "jane" || len(result.CCS) != 1 {
+		t.Errorf("MoveReviewerToCC() = %+v, want jane as CC", result)
+	}
+	if want := [][]ReviewerInput{{{Reviewer: "jane", State: ReviewerStateCC}}}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %+v, want %+v", requests, want)
+	}
+}
+
+func TestReviewResultFromError(t *testing.T) {
+	errorResponse := func(status int, message string) error {
+		return &ErrorResponse{Response: &http.Response{StatusCode: status}, Message: message}
+	}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"review result", errorResponse(http.StatusBadRequest, ")]}'\n{\"reviewers\":{\"x\":{\"error\":\"not found\"}}}"), true},
+		{"review result without prefix", errorResponse(http.StatusBadRequest, `{"reviewers":{"x":{"error":"not found"}}}`), true},
+		{"no reviewers", errorResponse(http.StatusBadRequest, `{"error":"bad"}`), false},
+		{"plain text", errorResponse(http.StatusBadRequest, "label not permitted"), false},
+		{"other status", errorResponse(http.StatusConflict, `{"reviewers":{"x":{"error":"not found"}}}`), false},
+		{"other error", errors.New("connection refused"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reviewResultFromError(tt.err); (got != nil) != tt.want {
+				t.Errorf("reviewResultFromError() = %+v, want a result %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,420 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// RobotCommentImportOptions specifies how findings of static analysis reports are converted to robot comments.
+type RobotCommentImportOptions struct {
+	// RobotID is the ID of the robot posting the comments.
+	// When empty, the tool name found in the report is used.
+	RobotID string
+
+	// RobotRunID identifies the run of the robot, e.g. the CI build number. It is required by Gerrit.
+	RobotRunID string
+
+	// URL points to more information about the run, e.g. the CI build page.
+	URL string
+
+	// PathPrefix is stripped from the file paths found in the report,
+	// so that absolute paths of the CI workspace become paths relative to the repository root.
+	PathPrefix string
+
+	// Unresolved marks the generated comments as unresolved.
+	Unresolved bool
+
+	// MaxComments caps the number of comments posted on a revision. Zero means no limit.
+	MaxComments int
+}
+
+// RobotCommentImportResult describes the outcome of ImportRevisionRobotComments.
+type RobotCommentImportResult struct {
+	// Posted is the number of robot comments sent to Gerrit.
+	Posted int
+
+	// Duplicates is the number of findings skipped because an identical robot comment already exists on the revision.
+	Duplicates int
+
+	// Dropped is the number of findings skipped because of RobotCommentImportOptions.MaxComments.
+	Dropped int
+
+	// Review is the result of the review that carried the comments; nil if there was nothing to post.
+	Review *ReviewResult
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name  string `json:"name"`
+			Rules []struct {
+				ID      string `json:"id"`
+				HelpURI string `json:"helpUri,omitempty"`
+			} `json:"rules,omitempty"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID    string       `json:"ruleId,omitempty"`
+	Level     string       `json:"level,omitempty"`
+	Message   sarifMessage `json:"message"`
+	Locations []struct {
+		PhysicalLocation struct {
+			ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+			Region           *sarifRegion          `json:"region,omitempty"`
+		} `json:"physicalLocation"`
+	} `json:"locations,omitempty"`
+	Fixes []struct {
+		Description     sarifMessage `json:"description"`
+		ArtifactChanges []struct {
+			ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+			Replacements     []struct {
+				DeletedRegion   sarifRegion   `json:"deletedRegion"`
+				InsertedContent *sarifMessage `json:"insertedContent,omitempty"`
+			} `json:"replacements"`
+		} `json:"artifactChanges"`
+	} `json:"fixes,omitempty"`
+}
+
+type checkstyleReport struct {
+	XMLName xml.Name `xml:"checkstyle"`
+	Files   []struct {
+		Name   string `xml:"name,attr"`
+		Errors []struct {
+			Line     int    `xml:"line,attr"`
+			Column   int    `xml:"column,attr"`
+			Severity string `xml:"severity,attr"`
+			Message  string `xml:"message,attr"`
+			Source   string `xml:"source,attr"`
+		} `xml:"error"`
+	} `xml:"file"`
+}
+
+// ParseSARIF converts the results of a SARIF 2.1 log into robot comments, keyed by file path.
+//
+// Result regions are mapped onto a CommentRange, and SARIF fixes become FixSuggestionInfo entities.
+// Fix changes without an artifact location apply to the file of their result.
+// Fixes with a replacement region that has no start line, e.g. one given by character offsets only, are dropped.
+// Results without a location in a file are skipped, since Gerrit robot comments must be attached to a file.
+//
+// SARIF docs: https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+func ParseSARIF(data []byte, opt *RobotCommentImportOptions) (map[string][]RobotCommentInput, error) {
+	if opt == nil {
+		opt = new(RobotCommentImportOptions)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, fmt.Errorf("parse SARIF: %w", err)
+	}
+	if log.Version != "" && !strings.HasPrefix(log.Version, "2.1") {
+		return nil, fmt.Errorf("parse SARIF: unsupported version %q", log.Version)
+	}
+
+	comments := make(map[string][]RobotCommentInput)
+	for _, run := range log.Runs {
+		robotID := opt.RobotID
+		if robotID == "" {
+			robotID = run.Tool.Driver.Name
+		}
+		helpURIs := make(map[string]string)
+		for _, rule := range run.Tool.Driver.Rules {
+			helpURIs[rule.ID] = rule.HelpURI
+		}
+
+		for _, result := range run.Results {
+			if len(result.Locations) == 0 {
+				continue
+			}
+			location := result.Locations[0].PhysicalLocation
+			path := normalizeReportPath(location.ArtifactLocation.URI, opt.PathPrefix)
+			if path == "" {
+				continue
+			}
+
+			comment := newImportedRobotComment(robotID, result.RuleID, result.Level, result.Message.Text, opt)
+			if uri := helpURIs[result.RuleID]; uri != "" && opt.URL == "" {
+				comment.URL = uri
+			}
+			if location.Region != nil {
+				comment.Line, comment.Range = sarifRegionToRange(*location.Region)
+			}
+
+			for _, fix := range result.Fixes {
+				suggestion := FixSuggestionInfo{Description: fix.Description.Text}
+				mapped := true
+				for _, change := range fix.ArtifactChanges {
+					// The artifact location of a change is optional; it then is the file of the result.
+					fixPath := normalizeReportPath(change.ArtifactLocation.URI, opt.PathPrefix)
+					if fixPath == "" {
+						fixPath = path
+					}
+					for _, replacement := range change.Replacements {
+						inserted := ""
+						if replacement.InsertedContent != nil {
+							inserted = replacement.InsertedContent.Text
+						}
+						r, text, ok := sarifDeletedRegionToRange(replacement.DeletedRegion, inserted)
+						if !ok {
+							mapped = false
+							continue
+						}
+						suggestion.Replacements = append(suggestion.Replacements, FixReplacementInfo{Path: fixPath, Range: r, Replacement: text})
+					}
+				}
+				// A fix is only useful as a whole: one with a replacement that cannot be mapped is dropped.
+				if !mapped || len(suggestion.Replacements) == 0 {
+					continue
+				}
+				if suggestion.Description == "" {
+					suggestion.Description = "Apply suggested fix"
+				}
+				comment.FixSuggestions = append(comment.FixSuggestions, suggestion)
+			}
+
+			comments[path] = append(comments[path], comment)
+		}
+	}
+
+	return comments, nil
+}
+
+// ParseCheckstyle converts a checkstyle XML report, as produced by golangci-lint, SpotBugs and friends,
+// into robot comments, keyed by file path.
+//
+// Checkstyle reports carry no end positions, so the comments are attached to a line rather than a range.
+func ParseCheckstyle(data []byte, opt *RobotCommentImportOptions) (map[string][]RobotCommentInput, error) {
+	if opt == nil {
+		opt = new(RobotCommentImportOptions)
+	}
+
+	var report checkstyleReport
+	if err := xml.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("parse checkstyle: %w", err)
+	}
+
+	robotID := opt.RobotID
+	if robotID == "" {
+		robotID = "checkstyle"
+	}
+
+	comments := make(map[string][]RobotCommentInput)
+	for _, file := range report.Files {
+		path := normalizeReportPath(file.Name, opt.PathPrefix)
+		if path == "" {
+			continue
+		}
+		for _, e := range file.Errors {
+			comment := newImportedRobotComment(robotID, e.Source, e.Severity, e.Message, opt)
+			comment.Line = e.Line
+			comments[path] = append(comments[path], comment)
+		}
+	}
+
+	return comments, nil
+}
+
+// ImportRevisionRobotComments posts robot comments, typically produced by ParseSARIF or ParseCheckstyle, on a revision.
+//
+// Findings that already exist as robot comments on the revision (same robot, file, position and message)
+// are skipped, and the remaining ones are capped to opt.MaxComments.
+// All comments are submitted with SetRevisionReview in a single ReviewInput; the remaining fields of input,
+// if given, are sent along with them.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#set-review
+func (c *Change) ImportRevisionRobotComments(ctx context.Context, revisionID string, comments map[string][]RobotCommentInput, opt *RobotCommentImportOptions, input *ReviewInput) (*RobotCommentImportResult, *http.Response, error) {
+	if opt == nil {
+		opt = new(RobotCommentImportOptions)
+	}
+	if opt.RobotRunID == "" {
+		return nil, nil, errors.New("import robot comments: RobotRunID is required")
+	}
+
+	existing, resp, err := c.ListRevisionRobotComments(ctx, revisionID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	seen := make(map[string]bool)
+	for path, list := range existing {
+		for _, comment := range list {
+			seen[robotCommentKey(path, comment.RobotID, comment.Line, comment.Range, comment.Message)] = true
+		}
+	}
+
+	// Sort the paths so that the cap drops the same findings on every run.
+	paths := make([]string, 0, len(comments))
+	for path := range comments {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	result := new(RobotCommentImportResult)
+	filtered := make(map[string][]RobotCommentInput)
+	for _, path := range paths {
+		list := append([]RobotCommentInput(nil), comments[path]...)
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Line < list[j].Line })
+
+		for _, comment := range list {
+			if comment.RobotRunID == "" {
+				comment.RobotRunID = opt.RobotRunID
+			}
+			key := robotCommentKey(path, comment.RobotID, comment.Line, comment.Range, comment.Message)
+			if seen[key] {
+				result.Duplicates++
+				continue
+			}
+			seen[key] = true
+
+			if opt.MaxComments > 0 && result.Posted >= opt.MaxComments {
+				result.Dropped++
+				continue
+			}
+			filtered[path] = append(filtered[path], comment)
+			result.Posted++
+		}
+	}
+
+	if result.Posted == 0 {
+		return result, resp, nil
+	}
+
+	review := ReviewInput{}
+	if input != nil {
+		review = *input
+	}
+	if result.Dropped > 0 && review.Message == "" {
+		review.Message = fmt.Sprintf("%d more findings were not posted as comments.", result.Dropped)
+	}
+
+	result.Review, resp, err = c.AddRevisionRobotComments(ctx, revisionID, filtered, &review)
+	if err != nil {
+		return nil, resp, err
+	}
+	return result, resp, nil
+}
+
+// newImportedRobotComment builds the robot comment shared by the SARIF and checkstyle converters.
+func newImportedRobotComment(robotID, rule, severity, message string, opt *RobotCommentImportOptions) RobotCommentInput {
+	if rule != "" {
+		message = fmt.Sprintf("%s: %s", rule, message)
+	}
+	if severity != "" {
+		message = fmt.Sprintf("[%s] %s", strings.ToLower(severity), message)
+	}
+
+	properties := make(map[string]*string)
+	if rule != "" {
+		properties["rule"] = &rule
+	}
+	if severity != "" {
+		properties["severity"] = &severity
+	}
+
+	comment := RobotCommentInput{
+		CommentInput: CommentInput{Message: message, Unresolved: opt.Unresolved},
+		RobotID:      robotID,
+		RobotRunID:   opt.RobotRunID,
+		URL:          opt.URL,
+	}
+	if len(properties) > 0 {
+		comment.Properties = &properties
+	}
+	return comment
+}
+
+// normalizeReportPath turns a file reference of a report into a path relative to the repository root.
+func normalizeReportPath(uri, prefix string) string {
+	path := strings.TrimPrefix(uri, "file://")
+	if prefix != "" {
+		path = strings.TrimPrefix(path, prefix)
+	}
+	path = strings.TrimPrefix(path, "./")
+	return strings.TrimLeft(path, "/")
+}
+
+// sarifRegionToRange converts a SARIF region, whose lines and columns are 1-based, to the line and
+// CommentRange of a Gerrit comment, whose characters are 0-based. Regions without columns are attached to their first line.
+func sarifRegionToRange(region sarifRegion) (int, *CommentRange) {
+	if region.StartLine == 0 {
+		return 0, nil
+	}
+	endLine := region.EndLine
+	if endLine == 0 {
+		endLine = region.StartLine
+	}
+	if region.StartColumn == 0 || region.EndColumn == 0 {
+		return region.StartLine, nil
+	}
+	return endLine, &CommentRange{
+		StartLine:      region.StartLine,
+		StartCharacter: region.StartColumn - 1,
+		EndLine:        endLine,
+		EndCharacter:   region.EndColumn - 1,
+	}
+}
+
+// sarifDeletedRegionToRange converts the deleted region of a SARIF replacement to the range of a FixReplacementInfo,
+// and returns the text to insert in its place.
+//
+// A region without endColumn runs to the end of its last line. As the length of that line is unknown, the range is
+// extended to the start of the following line and the line break it then deletes is appended to the inserted text.
+// Regions given by character offsets only, without startLine, cannot be mapped and false is returned.
+func sarifDeletedRegionToRange(region sarifRegion, inserted string) (CommentRange, string, bool) {
+	if region.StartLine == 0 {
+		return CommentRange{}, "", false
+	}
+	endLine := region.EndLine
+	if endLine == 0 {
+		endLine = region.StartLine
+	}
+	startColumn := region.StartColumn
+	if startColumn == 0 {
+		startColumn = 1
+	}
+
+	r := CommentRange{StartLine: region.StartLine, StartCharacter: startColumn - 1, EndLine: endLine}
+	if region.EndColumn == 0 {
+		r.EndLine++
+		return r, inserted + "\n", true
+	}
+	r.EndCharacter = region.EndColumn - 1
+	return r, inserted, true
+}
+
+// robotCommentKey identifies a robot comment for deduplication purposes.
+func robotCommentKey(path, robotID string, line int, r *CommentRange, message string) string {
+	position := fmt.Sprintf("%d", line)
+	if r != nil {
+		position = fmt.Sprintf("%d:%d-%d:%d", r.StartLine, r.StartCharacter, r.EndLine, r.EndCharacter)
+	}
+	return strings.Join([]string{path, robotID, position, message}, "\x00")
+}
@@ -0,0 +1,190 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const sarifReport = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "staticcheck", "rules": [{"id": "SA4006", "helpUri": "https://staticcheck.dev/docs/checks#SA4006"}]}},
+    "results": [
+      {
+        "ruleId": "SA4006",
+        "level": "warning",
+        "message": {"text": "value of err is never used"},
+        "locations": [{"physicalLocation": {
+          "artifactLocation": {"uri": "file:///workspace/pkg/main.go"},
+          "region": {"startLine": 10, "startColumn": 2, "endLine": 10, "endColumn": 5}
+        }}],
+        "fixes": [
+          {
+            "description": {"text": "Drop the assignment"},
+            "artifactChanges": [{
+              "artifactLocation": {},
+              "replacements": [
+                {"deletedRegion": {"startLine": 10, "startColumn": 2, "endColumn": 9}, "insertedContent": {"text": "_"}},
+                {"deletedRegion": {"startLine": 12}}
+              ]
+            }]
+          },
+          {
+            "artifactChanges": [{
+              "artifactLocation": {"uri": "file:///workspace/pkg/main.go"},
+              "replacements": [
+                {"deletedRegion": {"startLine": 10, "startColumn": 2, "endColumn": 5}, "insertedContent": {"text": "_"}},
+                {"deletedRegion": {"charOffset": 120, "charLength": 3}}
+              ]
+            }]
+          }
+        ]
+      },
+      {
+        "message": {"text": "file is too long"},
+        "locations": [{"physicalLocation": {
+          "artifactLocation": {"uri": "pkg/util.go"},
+          "region": {"startLine": 3}
+        }}]
+      },
+      {
+        "message": {"text": "module has no license"}
+      }
+    ]
+  }]
+}`
+
+func TestParseSARIF(t *testing.T) {
+	comments, err := ParseSARIF([]byte(sarifReport), &RobotCommentImportOptions{RobotRunID: "7", PathPrefix: "/workspace/"})
+	if err != nil {
+		t.Fatalf("ParseSARIF() error = %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("ParseSARIF() returned comments on %d files, want 2", len(comments))
+	}
+
+	main := comments["pkg/main.go"]
+	if len(main) != 1 {
+		t.Fatalf("ParseSARIF() returned %d comments on pkg/main.go, want 1", len(main))
+	}
+	c := main[0]
+	if c.RobotID != "staticcheck" || c.RobotRunID != "7" || c.URL != "https://staticcheck.dev/docs/checks#SA4006" {
+		t.Errorf("comment = robot %q, run %q, URL %q", c.RobotID, c.RobotRunID, c.URL)
+	}
+	if want := "[warning] SA4006: value of err is never used"; c.Message != want {
+		t.Errorf("Message = %q, want %q", c.Message, want)
+	}
+	if want := (&CommentRange{StartLine: 10, StartCharacter: 1, EndLine: 10, EndCharacter: 4}); c.Line != 10 || !reflect.DeepEqual(c.Range, want) {
+		t.Errorf("position = line %d, range %+v, want line 10, range %+v", c.Line, c.Range, want)
+	}
+
+	// The second fix has a replacement given by character offsets only, so it is dropped as a whole.
+	want := []FixSuggestionInfo{{
+		Description: "Drop the assignment",
+		Replacements: []FixReplacementInfo{
+			{Path: "pkg/main.go", Range: CommentRange{StartLine: 10, StartCharacter: 1, EndLine: 10, EndCharacter: 8}, Replacement: "_"},
+			{Path: "pkg/main.go", Range: CommentRange{StartLine: 12, StartCharacter: 0, EndLine: 13, EndCharacter: 0}, Replacement: "\n"},
+		},
+	}}
+	if !reflect.DeepEqual(c.FixSuggestions, want) {
+		t.Errorf("FixSuggestions = %+v, want %+v", c.FixSuggestions, want)
+	}
+
+	util := comments["pkg/util.go"]
+	if len(util) != 1 || util[0].Line != 3 || util[0].Range != nil || util[0].Message != "file is too long" {
+		t.Errorf("comments on pkg/util.go = %+v, want one on line 3 without a range", util)
+	}
+}
+
+func TestParseSARIFVersion(t *testing.T) {
+	if _, err := ParseSARIF([]byte(`{"version": "1.0.0", "runs": []}`), nil); err == nil {
+		t.Error("ParseSARIF() of a SARIF 1.0 log succeeded, want an error")
+	}
+}
+
+func TestParseCheckstyle(t *testing.T) {
+	report := `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="5.0">
+  <file name="/src/app/main.go">
+    <error line="4" column="1" severity="error" message="exported function Run should have comment" source="golint"></error>
+    <error line="9" severity="warning" message="line is too long"></error>
+  </file>
+  <file name="/src/app/empty.go"></file>
+</checkstyle>`
+
+	comments, err := ParseCheckstyle([]byte(report), &RobotCommentImportOptions{RobotRunID: "7", PathPrefix: "/src/app", Unresolved: true})
+	if err != nil {
+		t.Fatalf("ParseCheckstyle() error = %v", err)
+	}
+
+	var got []string
+	for _, c := range comments["main.go"] {
+		if c.RobotID != "checkstyle" || !c.Unresolved {
+			t.Errorf("comment = robot %q, unresolved %v, want checkstyle, true", c.RobotID, c.Unresolved)
+		}
+		got = append(got, c.Message)
+	}
+	want := []string{"[error] golint: exported function Run should have comment", "[warning] line is too long"}
+	if len(comments) != 1 || !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseCheckstyle() = %d files, messages %q, want 1 file, messages %q", len(comments), got, want)
+	}
+	if lines := []int{comments["main.go"][0].Line, comments["main.go"][1].Line}; lines[0] != 4 || lines[1] != 9 {
+		t.Errorf("lines = %v, want [4 9]", lines)
+	}
+}
+
+func TestImportRevisionRobotComments(t *testing.T) {
+	var review ReviewInput
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /changes/42/revisions/current/robotcomments/":
+			writeJSON(w, map[string][]RobotCommentInfo{
+				"a.go": {{CommentInfo: CommentInfo{Line: 1, Message: "first"}, RobotID: "vet"}},
+			})
+		case "POST /changes/42/revisions/current/review":
+			readJSON(t, r, &review)
+			writeJSON(w, ReviewResult{})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	finding := func(line int, message string) RobotCommentInput {
+		return RobotCommentInput{CommentInput: CommentInput{Line: line, Message: message}, RobotID: "vet"}
+	}
+	comments := map[string][]RobotCommentInput{
+		"b.go": {finding(5, "fifth"), finding(2, "second")},
+		"a.go": {finding(1, "first"), finding(3, "third"), finding(3, "third")},
+	}
+
+	result, _, err := NewChange(client, "42").ImportRevisionRobotComments(context.Background(), "current", comments, &RobotCommentImportOptions{RobotRunID: "7", MaxComments: 2}, nil)
+	if err != nil {
+		t.Fatalf("ImportRevisionRobotComments() error = %v", err)
+	}
+	if result.Posted != 2 || result.Duplicates != 2 || result.Dropped != 1 {
+		t.Errorf("result = posted %d, duplicates %d, dropped %d, want 2, 2, 1", result.Posted, result.Duplicates, result.Dropped)
+	}
+
+	// Paths and lines are sorted, so the cap always drops the last findings.
+	var got []string
+	for _, path := range []string{"a.go", "b.go"} {
+		for _, c := range review.RobotComments[path] {
+			if c.RobotRunID != "7" {
+				t.Errorf("RobotRunID = %q, want 7", c.RobotRunID)
+			}
+			got = append(got, path+":"+c.Message)
+		}
+	}
+	if want := []string{"a.go:third", "b.go:second"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("posted comments = %q, want %q", got, want)
+	}
+	if review.Message != "1 more findings were not posted as comments." {
+		t.Errorf("review message = %q", review.Message)
+	}
+
+	if _, _, err := NewChange(client, "42").ImportRevisionRobotComments(context.Background(), "current", comments, nil, nil); err == nil {
+		t.Error("ImportRevisionRobotComments() without a run ID succeeded, want an error")
+	}
+}
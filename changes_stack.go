@@ -0,0 +1,288 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// StackEntry is a change of a relation chain, at the patch set that is part of the chain.
+type StackEntry struct {
+	ChangeNumber    int
+	ChangeID        string
+	Commit          string
+	Subject         string
+	Status          string
+	PatchSet        int
+	CurrentPatchSet int
+
+	// Outdated is true when the chain contains an older patch set than the current one of the change.
+	// Changes stacked on an outdated entry need a rebase.
+	Outdated bool
+
+	// NeedsRebase is true when the entry is not based on the current state of its parent:
+	// its parent change has a newer patch set, was abandoned, or was merged as a different commit.
+	NeedsRebase bool
+}
+
+// Open reports whether the change of the entry can still be reviewed and submitted.
+func (e StackEntry) Open() bool {
+	return e.Status == "" || e.Status == "NEW"
+}
+
+// Stack is a relation chain of dependent changes, ordered from the bottom (closest to the target branch) to the top.
+type Stack struct {
+	gerrit *Gerrit
+
+	// Entries are the changes of the stack, bottom-up.
+	Entries []StackEntry
+
+	// Index is the position in Entries of the change the stack was retrieved for.
+	Index int
+}
+
+// GetStack retrieves the relation chain a revision is part of.
+//
+// The stack is built from GetRevisionRelatedChanges, which lists the chain top-down, and
+// the ParentInfo of the bottom change, which tells whether the chain is still based on the target branch.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#get-related-changes
+func (c *Change) GetStack(ctx context.Context, revisionID string) (*Stack, *http.Response, error) {
+	if revisionID == "" {
+		revisionID = "current"
+	}
+
+	related, resp, err := c.GetRevisionRelatedChanges(ctx, revisionID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	stack := &Stack{gerrit: c.gerrit}
+	if len(related.Changes) == 0 {
+		// A change without relations is a stack of its own.
+		info, resp, err := c.GetDetail(ctx, &ChangeOptions{AdditionalFields: []string{"CURRENT_REVISION", "CURRENT_COMMIT"}})
+		if err != nil {
+			return nil, resp, err
+		}
+		revision := info.Revisions[info.CurrentRevision]
+		stack.Entries = []StackEntry{{
+			ChangeNumber:    info.Number,
+			ChangeID:        info.ChangeID,
+			Commit:          info.CurrentRevision,
+			Subject:         info.Subject,
+			Status:          info.Status,
+			PatchSet:        revision.Number,
+			CurrentPatchSet: revision.Number,
+		}}
+		return stack, resp, nil
+	}
+
+	self := c.Base
+	if c.Raw != nil && c.Raw.Number != 0 {
+		self = strconv.Itoa(c.Raw.Number)
+	}
+
+	// Related changes are listed top-down, reverse them to get the bottom-up order.
+	for i := len(related.Changes) - 1; i >= 0; i-- {
+		r := related.Changes[i]
+		entry := StackEntry{
+			ChangeNumber:    r.ChangeNumber,
+			ChangeID:        r.ChangeID,
+			Commit:          r.Commit.Commit,
+			Subject:         r.Commit.Subject,
+			Status:          r.Status,
+			PatchSet:        r.RevisionNumber,
+			CurrentPatchSet: r.CurrentRevisionNumber,
+			Outdated:        r.RevisionNumber != 0 && r.RevisionNumber < r.CurrentRevisionNumber,
+		}
+		if n := len(stack.Entries); n > 0 {
+			parent := stack.Entries[n-1]
+			entry.NeedsRebase = parent.Outdated || parent.NeedsRebase || parent.Status == "ABANDONED"
+		}
+		if strconv.Itoa(entry.ChangeNumber) == self || strings.HasSuffix(self, "~"+strconv.Itoa(entry.ChangeNumber)) || (entry.ChangeID != "" && strings.HasSuffix(self, entry.ChangeID)) {
+			stack.Index = len(stack.Entries)
+		}
+		stack.Entries = append(stack.Entries, entry)
+	}
+
+	// Only the bottom change tells whether the chain still sits on its parent.
+	// Servers older than 3.10 don't know the PARENTS option and answer "400 Bad Request".
+	bottom := NewChange(c.gerrit, strconv.Itoa(stack.Entries[0].ChangeNumber))
+	info, presp, err := bottom.GetDetail(ctx, &ChangeOptions{AdditionalFields: []string{"CURRENT_REVISION", "PARENTS"}})
+	if err != nil {
+		if presp != nil && presp.StatusCode == http.StatusBadRequest {
+			return stack, resp, nil
+		}
+		return nil, presp, err
+	}
+	resp = presp
+	for _, parent := range info.Revisions[info.CurrentRevision].ParentsData {
+		if parentNeedsRebase(parent) {
+			stack.Entries[0].NeedsRebase = true
+			for i := 1; i < len(stack.Entries); i++ {
+				stack.Entries[i].NeedsRebase = true
+			}
+		}
+	}
+
+	return stack, resp, nil
+}
+
+// Ancestors returns the changes below the change the stack was retrieved for, bottom-up.
+func (s *Stack) Ancestors() []StackEntry {
+	return s.Entries[:s.Index]
+}
+
+// Descendants returns the changes above the change the stack was retrieved for, bottom-up.
+func (s *Stack) Descendants() []StackEntry {
+	return s.Entries[s.Index+1:]
+}
+
+// Outdated returns the changes of the stack that are not part of it at their current patch set.
+func (s *Stack) Outdated() []StackEntry {
+	var entries []StackEntry
+	for _, entry := range s.Entries {
+		if entry.Outdated {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// NeedsRebase returns the changes of the stack that need a rebase.
+func (s *Stack) NeedsRebase() []StackEntry {
+	var entries []StackEntry
+	for _, entry := range s.Entries {
+		if entry.NeedsRebase {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Rebase rebases the open changes of the stack bottom-up, starting from the lowest change that needs a rebase.
+// Each change is rebased with RebaseRevision onto the current patch set of the change below it,
+// or onto the target branch for the bottom change.
+//
+// When a rebase fails, e.g. because of conflicts, the changes above it are skipped.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#rebase-revision
+func (s *Stack) Rebase(ctx context.Context, input *RebaseInput) []ChangeOperationResult {
+	results := make([]ChangeOperationResult, 0, len(s.Entries))
+
+	rebasing := false
+	failed := false
+	previous := 0
+	for _, entry := range s.Entries {
+		result := ChangeOperationResult{ChangeNumber: entry.ChangeNumber}
+		switch {
+		case failed:
+			result.Skipped, result.Reason = true, "a change below failed to rebase"
+		case !entry.Open():
+			result.Skipped, result.Reason = true, "change is "+strings.ToLower(entry.Status)
+		case !rebasing && !entry.NeedsRebase:
+			result.Skipped, result.Reason = true, "change is up to date"
+		default:
+			rebasing = true
+
+			in := RebaseInput{}
+			if input != nil {
+				in = *input
+			}
+			if previous != 0 {
+				in.Base = strconv.Itoa(previous)
+			}
+			change := NewChange(s.gerrit, strconv.Itoa(entry.ChangeNumber))
+			result.Change, _, result.Err = change.RebaseRevision(ctx, "current", &in)
+			if result.Err != nil {
+				if isUpToDateError(result.Err) {
+					result.Err = nil
+					result.Skipped, result.Reason = true, "change is up to date"
+				} else {
+					failed = true
+				}
+			}
+		}
+		if entry.Open() {
+			previous = entry.ChangeNumber
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// Vote casts the same votes on the current patch set of every open change of the stack.
+// A failing vote does not prevent voting on the other changes.
+func (s *Stack) Vote(ctx context.Context, input *VoteInput) []ChangeOperationResult {
+	results := make([]ChangeOperationResult, 0, len(s.Entries))
+
+	for _, entry := range s.Entries {
+		result := ChangeOperationResult{ChangeNumber: entry.ChangeNumber}
+		if !entry.Open() {
+			result.Skipped, result.Reason = true, "change is "+strings.ToLower(entry.Status)
+			results = append(results, result)
+			continue
+		}
+
+		change := NewChange(s.gerrit, strconv.Itoa(entry.ChangeNumber))
+		vote, _, err := change.Vote(ctx, "current", input)
+		result.Err = err
+		if vote != nil && vote.Review != nil {
+			info := vote.Review.ChangeInfo
+			result.Change = &info
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// Submit submits the open changes of the stack one by one, bottom-up.
+// When a change cannot be submitted, the changes above it are skipped.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#submit-change
+func (s *Stack) Submit(ctx context.Context, input *SubmitInput) []ChangeOperationResult {
+	results := make([]ChangeOperationResult, 0, len(s.Entries))
+
+	failed := false
+	for _, entry := range s.Entries {
+		result := ChangeOperationResult{ChangeNumber: entry.ChangeNumber}
+		switch {
+		case failed:
+			result.Skipped, result.Reason = true, "a change below failed to submit"
+		case !entry.Open():
+			result.Skipped, result.Reason = true, "change is "+strings.ToLower(entry.Status)
+		default:
+			change := NewChange(s.gerrit, strconv.Itoa(entry.ChangeNumber))
+			result.Change, _, result.Err = change.Submit(ctx, input)
+			failed = result.Err != nil
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// parentNeedsRebase reports whether a patch set based on parent must be rebased to apply to its target branch.
+func parentNeedsRebase(parent ParentInfo) bool {
+	if parent.ChangeNumber == 0 {
+		// The parent is a commit of the target branch.
+		return !parent.IsMergedInTargetBranch
+	}
+	switch parent.ChangeStatus {
+	case "ABANDONED":
+		return true
+	case "MERGED":
+		return !parent.IsMergedInTargetBranch
+	}
+	return false
+}
+
+// isUpToDateError reports whether err is Gerrit refusing to rebase a change that is already up to date.
+func isUpToDateError(err error) bool {
+	var e *ErrorResponse
+	return errors.As(err, &e) && e.Response.StatusCode == http.StatusConflict && strings.Contains(e.Message, "up to date")
+}
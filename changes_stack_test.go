@@ -0,0 +1,215 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParentNeedsRebase(t *testing.T) {
+	tests := []struct {
+		name   string
+		parent ParentInfo
+		want   bool
+	}{
+		{"branch tip", ParentInfo{CommitID: "a1", IsMergedInTargetBranch: true}, false},
+		{"commit not in branch", ParentInfo{CommitID: "a1"}, true},
+		{"open parent change", ParentInfo{ChangeNumber: 10, ChangeStatus: "NEW"}, false},
+		{"abandoned parent change", ParentInfo{ChangeNumber: 10, ChangeStatus: "ABANDONED"}, true},
+		{"merged parent change", ParentInfo{ChangeNumber: 10, ChangeStatus: "MERGED", IsMergedInTargetBranch: true}, false},
+		{"parent merged as another commit", ParentInfo{ChangeNumber: 10, ChangeStatus: "MERGED"}, true},
+	}
+	for _, tt := range tests {
+		if got := parentNeedsRebase(tt.parent); got != tt.want {
+			t.Errorf("%s: parentNeedsRebase() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsUpToDateError(t *testing.T) {
+	conflict := func(message string) error {
+		return &ErrorResponse{Response: &http.Response{StatusCode: http.StatusConflict}, Message: message}
+	}
+	if !isUpToDateError(conflict("Change is already up to date.")) {
+		t.Error("isUpToDateError() = false for an up to date change")
+	}
+	if isUpToDateError(conflict("The change could not be rebased due to a conflict during merge.")) {
+		t.Error("isUpToDateError() = true for a merge conflict")
+	}
+	if isUpToDateError(errors.New("up to date")) {
+		t.Error("isUpToDateError() = true for an error that is not a response")
+	}
+}
+
+func TestGetStack(t *testing.T) {
+	related := RelatedChangesInfo{Changes: []RelatedChangeAndCommitInfo{
+		{ChangeNumber: 13, RevisionNumber: 1, CurrentRevisionNumber: 1, Status: "NEW"},
+		{ChangeNumber: 12, RevisionNumber: 2, CurrentRevisionNumber: 2, Status: "NEW", ChangeID: "I12"},
+		{ChangeNumber: 11, RevisionNumber: 1, CurrentRevisionNumber: 2, Status: "NEW"},
+		{ChangeNumber: 10, RevisionNumber: 1, CurrentRevisionNumber: 1, Status: "NEW"},
+	}}
+
+	tests := []struct {
+		name        string
+		parent      ParentInfo
+		parentsCode int
+		outdated    []int
+		needsRebase []int
+	}{
+		{
+			name:        "based on the branch",
+			parent:      ParentInfo{CommitID: "a1", IsMergedInTargetBranch: true},
+			outdated:    []int{11},
+			needsRebase: []int{12, 13},
+		},
+		{
+			name:        "branch moved on",
+			parent:      ParentInfo{ChangeNumber: 9, ChangeStatus: "ABANDONED"},
+			outdated:    []int{11},
+			needsRebase: []int{10, 11, 12, 13},
+		},
+		{
+			name:        "server without PARENTS",
+			parentsCode: http.StatusBadRequest,
+			outdated:    []int{11},
+			needsRebase: []int{12, 13},
+		},
+	}
+
+	numbers := func(entries []StackEntry) []int {
+		var out []int
+		for _, entry := range entries {
+			out = append(out, entry.ChangeNumber)
+		}
+		return out
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/changes/myProject~12/revisions/current/related":
+					writeJSON(w, related)
+				case "/changes/10/detail":
+					if tt.parentsCode != 0 {
+						http.Error(w, "PARENTS is not a valid option", tt.parentsCode)
+						return
+					}
+					writeJSON(w, ChangeInfo{
+						CurrentRevision: "c10",
+						Revisions:       map[string]RevisionInfo{"c10": {ParentsData: []ParentInfo{tt.parent}}},
+					})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			})
+
+			stack, _, err := NewChange(client, "myProject~12").GetStack(context.Background(), "")
+			if err != nil {
+				t.Fatalf("GetStack() error = %v", err)
+			}
+			if got := numbers(stack.Entries); !reflect.DeepEqual(got, []int{10, 11, 12, 13}) {
+				t.Errorf("Entries = %v, want bottom-up [10 11 12 13]", got)
+			}
+			if got := numbers(stack.Ancestors()); !reflect.DeepEqual(got, []int{10, 11}) {
+				t.Errorf("Ancestors() = %v, want [10 11]", got)
+			}
+			if got := numbers(stack.Descendants()); !reflect.DeepEqual(got, []int{13}) {
+				t.Errorf("Descendants() = %v, want [13]", got)
+			}
+			if got := numbers(stack.Outdated()); !reflect.DeepEqual(got, tt.outdated) {
+				t.Errorf("Outdated() = %v, want %v", got, tt.outdated)
+			}
+			if got := numbers(stack.NeedsRebase()); !reflect.DeepEqual(got, tt.needsRebase) {
+				t.Errorf("NeedsRebase() = %v, want %v", got, tt.needsRebase)
+			}
+		})
+	}
+}
+
+// stackTestServer answers the operations of a stack with handler, keyed by change number.
+func stackTestServer(t *testing.T, operation string, handler func(number string, w http.ResponseWriter, r *http.Request)) *Gerrit {
+	return newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/changes/"), "/")
+		if r.Method != http.MethodPost || parts[len(parts)-1] != operation {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+		handler(parts[0], w, r)
+	})
+}
+
+func stackOutcomes(results []ChangeOperationResult) []string {
+	var out []string
+	for _, result := range results {
+		switch {
+		case result.Err != nil:
+			out = append(out, "error")
+		case result.Skipped:
+			out = append(out, result.Reason)
+		default:
+			out = append(out, "done")
+		}
+	}
+	return out
+}
+
+func TestStackRebase(t *testing.T) {
+	var bases []string
+	client := stackTestServer(t, "rebase", func(number string, w http.ResponseWriter, r *http.Request) {
+		var input RebaseInput
+		readJSON(t, r, &input)
+		bases = append(bases, number+" onto "+input.Base)
+		switch number {
+		case "13":
+			http.Error(w, "Change is already up to date.", http.StatusConflict)
+		case "14":
+			http.Error(w, "The change could not be rebased due to a conflict during merge.", http.StatusConflict)
+		default:
+			writeJSON(w, ChangeInfo{})
+		}
+	})
+
+	stack := &Stack{gerrit: client, Entries: []StackEntry{
+		{ChangeNumber: 10, Status: "MERGED"},
+		{ChangeNumber: 11, Status: "NEW"},
+		{ChangeNumber: 12, Status: "NEW", NeedsRebase: true},
+		{ChangeNumber: 13, Status: "NEW", NeedsRebase: true},
+		{ChangeNumber: 14, Status: "NEW", NeedsRebase: true},
+		{ChangeNumber: 15, Status: "NEW", NeedsRebase: true},
+	}}
+
+	got := stackOutcomes(stack.Rebase(context.Background(), nil))
+	want := []string{"change is merged", "change is up to date", "done", "change is up to date", "error", "a change below failed to rebase"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Rebase() = %q, want %q", got, want)
+	}
+	if want := []string{"12 onto 11", "13 onto 12", "14 onto 13"}; !reflect.DeepEqual(bases, want) {
+		t.Errorf("rebases = %q, want %q", bases, want)
+	}
+}
+
+func TestStackSubmit(t *testing.T) {
+	client := stackTestServer(t, "submit", func(number string, w http.ResponseWriter, r *http.Request) {
+		if number == "12" {
+			http.Error(w, "submit requirement Code-Review not satisfied", http.StatusConflict)
+			return
+		}
+		writeJSON(w, ChangeInfo{Status: "MERGED"})
+	})
+
+	stack := &Stack{gerrit: client, Entries: []StackEntry{
+		{ChangeNumber: 10, Status: "ABANDONED"},
+		{ChangeNumber: 11, Status: "NEW"},
+		{ChangeNumber: 12, Status: "NEW"},
+		{ChangeNumber: 13, Status: "NEW"},
+	}}
+
+	got := stackOutcomes(stack.Submit(context.Background(), nil))
+	want := []string{"change is abandoned", "done", "error", "a change below failed to submit"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Submit() = %q, want %q", got, want)
+	}
+}
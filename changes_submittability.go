@@ -0,0 +1,269 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SubmitBlockerKind describes why a change cannot be submitted.
+type SubmitBlockerKind string
+
+const (
+	BlockerChangeClosed       SubmitBlockerKind = "CHANGE_CLOSED"
+	BlockerWorkInProgress     SubmitBlockerKind = "WORK_IN_PROGRESS"
+	BlockerPrivate            SubmitBlockerKind = "PRIVATE"
+	BlockerSubmitRequirement  SubmitBlockerKind = "SUBMIT_REQUIREMENT"
+	BlockerMissingVote        SubmitBlockerKind = "MISSING_VOTE"
+	BlockerBlockingLabel      SubmitBlockerKind = "BLOCKING_LABEL"
+	BlockerMergeConflict      SubmitBlockerKind = "MERGE_CONFLICT"
+	BlockerUnresolvedComments SubmitBlockerKind = "UNRESOLVED_COMMENTS"
+)
+
+// SubmitBlocker is a single reason that keeps a change from being submitted.
+type SubmitBlocker struct {
+	Kind SubmitBlockerKind
+
+	// Label is the label a MISSING_VOTE or BLOCKING_LABEL reason refers to.
+	Label string
+
+	// Requirement is the name of the submit requirement a SUBMIT_REQUIREMENT reason refers to.
+	Requirement string
+
+	// FailingAtoms are the atoms of the submittability expression that are not fulfilled.
+	FailingAtoms []string
+
+	// Blocking is false for reasons that are worth knowing but do not prevent submission by themselves,
+	// e.g. a change being private.
+	Blocking bool
+
+	// Message is a human-readable explanation.
+	Message string
+}
+
+// SubmittabilityReport explains whether a change can be submitted and, if not, why.
+type SubmittabilityReport struct {
+	// Change is the change the report was computed from.
+	Change *ChangeInfo
+
+	// Submittable is the verdict of the server, as in ChangeInfo.Submittable.
+	Submittable bool
+
+	// Mergeable reports whether the current revision can be merged into its destination branch.
+	Mergeable bool
+
+	// Reasons lists everything that keeps the change from being submitted.
+	Reasons []SubmitBlocker
+}
+
+// Blockers returns the reasons that prevent the change from being submitted.
+func (r *SubmittabilityReport) Blockers() []SubmitBlocker {
+	var blockers []SubmitBlocker
+	for _, reason := range r.Reasons {
+		if reason.Blocking {
+			blockers = append(blockers, reason)
+		}
+	}
+	return blockers
+}
+
+// String formats the report as one line per reason.
+func (r *SubmittabilityReport) String() string {
+	if r.Submittable && len(r.Blockers()) == 0 {
+		return fmt.Sprintf("change %d is submittable", r.Change.Number)
+	}
+	lines := []string{fmt.Sprintf("change %d is not submittable:", r.Change.Number)}
+	for _, reason := range r.Reasons {
+		lines = append(lines, fmt.Sprintf("  - %s", reason.Message))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GetSubmittability explains why a change can or cannot be submitted.
+//
+// The change is retrieved with the labels, submittability and submit requirements options set,
+// and every failing submit requirement, missing vote, blocking label, work-in-progress or private state,
+// merge conflict and unresolved comment thread is reported as a structured reason.
+// When the server does not compute mergeability as part of the change, it is retrieved from the current revision.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#get-change-detail
+func (c *Change) GetSubmittability(ctx context.Context) (*SubmittabilityReport, *http.Response, error) {
+	opt := &ChangeOptions{AdditionalFields: []string{"DETAILED_LABELS", "SUBMITTABLE", "SUBMIT_REQUIREMENTS", "CURRENT_REVISION"}}
+	info, resp, err := c.GetDetail(ctx, opt)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	report := &SubmittabilityReport{Change: info, Submittable: info.Submittable, Mergeable: info.Mergeable}
+
+	if info.Status != "NEW" {
+		report.Reasons = append(report.Reasons, SubmitBlocker{
+			Kind:     BlockerChangeClosed,
+			Blocking: true,
+			Message:  fmt.Sprintf("change is %s", strings.ToLower(info.Status)),
+		})
+		return report, resp, nil
+	}
+
+	if info.WorkInProgress {
+		report.Reasons = append(report.Reasons, SubmitBlocker{
+			Kind:     BlockerWorkInProgress,
+			Blocking: true,
+			Message:  "change is work in progress",
+		})
+	}
+	if info.IsPrivate {
+		report.Reasons = append(report.Reasons, SubmitBlocker{
+			Kind:    BlockerPrivate,
+			Message: "change is private; submitting it makes it visible to everyone who can read the branch",
+		})
+	}
+
+	missing := make(map[string]bool)
+	blocking := make(map[string]bool)
+	unresolvedRequired := false
+	if len(info.SubmitRequirements) > 0 {
+		for _, requirement := range info.SubmitRequirements {
+			if requirement.Status != "UNSATISFIED" && requirement.Status != "ERROR" {
+				continue
+			}
+			expression := requirement.SubmittabilityExpressionResult
+			reason := SubmitBlocker{
+				Kind:         BlockerSubmitRequirement,
+				Requirement:  requirement.Name,
+				FailingAtoms: expression.FailingAtoms,
+				Blocking:     true,
+			}
+			if requirement.Status == "ERROR" {
+				reason.Message = fmt.Sprintf("submit requirement %q cannot be evaluated: %s", requirement.Name, strings.Join(expression.ErrorAtoms, ", "))
+			} else {
+				reason.Message = fmt.Sprintf("submit requirement %q is not satisfied: %s", requirement.Name, strings.Join(expression.FailingAtoms, ", "))
+			}
+			report.Reasons = append(report.Reasons, reason)
+
+			// Atoms are reported without the negation they have in the expression: a negated label atom,
+			// e.g. the "label:Code-Review=MIN" of "-label:Code-Review=MIN", is passing when a blocking vote was cast.
+			for _, atom := range expression.FailingAtoms {
+				if strings.Contains(atom, "unresolved") {
+					unresolvedRequired = true
+				}
+				if name, ok := parseLabelAtom(atom); ok && !negatedAtom(expression.Expression, atom) {
+					missing[name] = true
+				}
+			}
+			for _, atom := range expression.PassingAtoms {
+				if name, ok := parseLabelAtom(atom); ok && negatedAtom(expression.Expression, atom) {
+					blocking[name] = true
+				}
+			}
+		}
+	} else {
+		for name, label := range info.Labels {
+			if label.Optional {
+				continue
+			}
+			if label.Approved.AccountID == 0 {
+				missing[name] = true
+			}
+		}
+	}
+
+	for name, label := range info.Labels {
+		if label.Blocking || label.Rejected.AccountID != 0 {
+			blocking[name] = true
+		}
+	}
+
+	for _, name := range sortedMapKeys(blocking) {
+		label := info.Labels[name]
+		message := fmt.Sprintf("label %s is blocking", name)
+		if label.Rejected.AccountID != 0 {
+			message = fmt.Sprintf("label %s is blocked by %s", name, accountDisplayName(label.Rejected))
+		}
+		report.Reasons = append(report.Reasons, SubmitBlocker{Kind: BlockerBlockingLabel, Label: name, Blocking: true, Message: message})
+	}
+	for _, name := range sortedMapKeys(missing) {
+		if blocking[name] {
+			continue
+		}
+		report.Reasons = append(report.Reasons, SubmitBlocker{
+			Kind:     BlockerMissingVote,
+			Label:    name,
+			Blocking: true,
+			Message:  fmt.Sprintf("label %s needs an approving vote", name),
+		})
+	}
+
+	if !info.Mergeable {
+		mergeable, mresp, err := c.GetRevisionMergeable(ctx, "current", nil)
+		if err != nil {
+			return nil, mresp, err
+		}
+		report.Mergeable = mergeable.Mergeable
+	}
+	if !report.Mergeable || info.ContainsGitConflicts {
+		report.Mergeable = false
+		report.Reasons = append(report.Reasons, SubmitBlocker{
+			Kind:     BlockerMergeConflict,
+			Blocking: true,
+			Message:  fmt.Sprintf("change cannot be merged into %s and needs a rebase", info.Branch),
+		})
+	}
+
+	if info.UnresolvedCommentCount > 0 {
+		report.Reasons = append(report.Reasons, SubmitBlocker{
+			Kind:     BlockerUnresolvedComments,
+			Blocking: unresolvedRequired,
+			Message:  fmt.Sprintf("change has %d unresolved comment(s)", info.UnresolvedCommentCount),
+		})
+	}
+
+	return report, resp, nil
+}
+
+// parseLabelAtom extracts the label name of a submit requirement atom like "label:Code-Review=MAX,user=non_uploader".
+func parseLabelAtom(atom string) (name string, ok bool) {
+	if !strings.HasPrefix(atom, "label:") {
+		return "", false
+	}
+	name = strings.TrimPrefix(atom, "label:")
+	if i := strings.IndexAny(name, "=<>,"); i >= 0 {
+		name = name[:i]
+	}
+	return name, name != ""
+}
+
+// negatedAtom reports whether an atom is negated in a submit requirement expression,
+// as in "-label:Code-Review=MIN" or "NOT label:Code-Review=MIN".
+func negatedAtom(expression, atom string) bool {
+	for offset := 0; ; {
+		i := strings.Index(expression[offset:], atom)
+		if i < 0 {
+			return false
+		}
+		before := strings.TrimRight(expression[:offset+i], " (")
+		if strings.HasSuffix(before, "-") {
+			return true
+		}
+		if rest, ok := strings.CutSuffix(strings.ToUpper(before), "NOT"); ok && (rest == "" || strings.HasSuffix(rest, " ") || strings.HasSuffix(rest, "(")) {
+			return true
+		}
+		offset += i + len(atom)
+	}
+}
+
+// accountDisplayName returns the most readable identifier of an account.
+func accountDisplayName(account AccountInfo) string {
+	switch {
+	case account.DisplayName != "":
+		return account.DisplayName
+	case account.Name != "":
+		return account.Name
+	case account.Email != "":
+		return account.Email
+	case account.Username != "":
+		return account.Username
+	}
+	return fmt.Sprintf("account %d", account.AccountID)
+}
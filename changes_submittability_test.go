@@ -0,0 +1,153 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestParseLabelAtom(t *testing.T) {
+	tests := []struct {
+		atom string
+		want string
+		ok   bool
+	}{
+		{"label:Code-Review=MAX,user=non_uploader", "Code-Review", true},
+		{"label:Verified>=1", "Verified", true},
+		{"label:Code-Review", "Code-Review", true},
+		{"label:=MAX", "", false},
+		{"is:unresolved", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := parseLabelAtom(tt.atom)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseLabelAtom(%q) = %q, %v, want %q, %v", tt.atom, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestNegatedAtom(t *testing.T) {
+	const atom = "label:Code-Review=MIN"
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"label:Code-Review=MAX AND -label:Code-Review=MIN", true},
+		{"label:Code-Review=MAX AND NOT label:Code-Review=MIN", true},
+		{"label:Code-Review=MAX AND not (label:Code-Review=MIN)", true},
+		{"-(label:Code-Review=MIN)", true},
+		{"label:Code-Review=MIN OR label:Verified=MAX", false},
+		{"CANNOT label:Code-Review=MIN", false},
+		{"label:Verified=MAX", false},
+	}
+	for _, tt := range tests {
+		if got := negatedAtom(tt.expression, atom); got != tt.want {
+			t.Errorf("negatedAtom(%q) = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+}
+
+func TestAccountDisplayName(t *testing.T) {
+	tests := []struct {
+		account AccountInfo
+		want    string
+	}{
+		{AccountInfo{AccountID: 1, DisplayName: "Jane", Name: "Jane Doe"}, "Jane"},
+		{AccountInfo{AccountID: 1, Name: "Jane Doe", Email: "jane@example.com"}, "Jane Doe"},
+		{AccountInfo{AccountID: 1, Email: "jane@example.com", Username: "jane"}, "jane@example.com"},
+		{AccountInfo{AccountID: 1, Username: "jane"}, "jane"},
+		{AccountInfo{AccountID: 1}, "account 1"},
+	}
+	for _, tt := range tests {
+		if got := accountDisplayName(tt.account); got != tt.want {
+			t.Errorf("accountDisplayName(%+v) = %q, want %q", tt.account, got, tt.want)
+		}
+	}
+}
+
+func TestGetSubmittability(t *testing.T) {
+	info := ChangeInfo{
+		Number:                 42,
+		Status:                 "NEW",
+		Branch:                 "main",
+		WorkInProgress:         true,
+		UnresolvedCommentCount: 2,
+		Labels: map[string]LabelInfo{
+			"Code-Review": {Rejected: AccountInfo{AccountID: 7, Name: "Jane Doe"}},
+			"Verified":    {},
+		},
+		SubmitRequirements: []SubmitRequirementResultInfo{
+			{
+				Name:   "Code-Review",
+				Status: "UNSATISFIED",
+				SubmittabilityExpressionResult: SubmitRequirementExpressionInfo{
+					Expression:   "label:Code-Review=MAX AND -label:Code-Review=MIN",
+					PassingAtoms: []string{"label:Code-Review=MIN"},
+					FailingAtoms: []string{"label:Code-Review=MAX"},
+				},
+			},
+			{
+				Name:   "Verified",
+				Status: "UNSATISFIED",
+				SubmittabilityExpressionResult: SubmitRequirementExpressionInfo{
+					Expression:   "label:Verified=MAX AND -is:unresolved",
+					FailingAtoms: []string{"label:Verified=MAX", "is:unresolved"},
+				},
+			},
+			{Name: "No-Unresolved", Status: "SATISFIED"},
+		},
+	}
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/changes/42/detail":
+			writeJSON(w, info)
+		case "/changes/42/revisions/current/mergeable":
+			writeJSON(w, MergeableInfo{Mergeable: false})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	report, _, err := NewChange(client, "42").GetSubmittability(context.Background())
+	if err != nil {
+		t.Fatalf("GetSubmittability() error = %v", err)
+	}
+
+	var got []string
+	for _, reason := range report.Reasons {
+		got = append(got, reason.Message)
+	}
+	want := []string{
+		"change is work in progress",
+		`submit requirement "Code-Review" is not satisfied: label:Code-Review=MAX`,
+		`submit requirement "Verified" is not satisfied: label:Verified=MAX, is:unresolved`,
+		"label Code-Review is blocked by Jane Doe",
+		"label Verified needs an approving vote",
+		"change cannot be merged into main and needs a rebase",
+		"change has 2 unresolved comment(s)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("reasons =\n%q\nwant\n%q", got, want)
+	}
+	if n := len(report.Blockers()); n != len(want) {
+		t.Errorf("Blockers() returned %d reasons, want %d", n, len(want))
+	}
+	if report.Mergeable {
+		t.Error("Mergeable = true, want false")
+	}
+}
+
+func TestGetSubmittabilityClosed(t *testing.T) {
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, ChangeInfo{Number: 42, Status: "MERGED"})
+	})
+
+	report, _, err := NewChange(client, "42").GetSubmittability(context.Background())
+	if err != nil {
+		t.Fatalf("GetSubmittability() error = %v", err)
+	}
+	if len(report.Reasons) != 1 || report.Reasons[0].Kind != BlockerChangeClosed || report.Reasons[0].Message != "change is merged" {
+		t.Errorf("reasons = %+v, want only a CHANGE_CLOSED reason", report.Reasons)
+	}
+}
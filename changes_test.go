@@ -0,0 +1,64 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestRevertSubmission(t *testing.T) {
+	var input RevertInput
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /changes/":
+			if q := r.URL.Query().Get("q"); q != `submissionid:"42-1700000000000-abc"` {
+				t.Errorf("query = %q", q)
+			}
+			writeJSON(w, []ChangeInfo{{ID: "p~42", Number: 42}, {ID: "q~43", Number: 43}})
+		case "POST /changes/p~42/revert_submission":
+			readJSON(t, r, &input)
+			writeJSON(w, RevertSubmissionInfo{RevertChanges: []ChangeInfo{{Number: 44}, {Number: 45}}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	reverts, changes, _, err := client.Changes.RevertSubmission(context.Background(), "42-1700000000000-abc", &RevertInput{Message: "Revert the release"})
+	if err != nil {
+		t.Fatalf("RevertSubmission() error = %v", err)
+	}
+	if len(changes) != 2 || len(reverts.RevertChanges) != 2 {
+		t.Errorf("RevertSubmission() = %d reverts of %d changes, want 2 of 2", len(reverts.RevertChanges), len(changes))
+	}
+	if input.Topic != "revert-42-1700000000000-abc" || input.Message != "Revert the release" {
+		t.Errorf("input = %+v, want the default topic and the message", input)
+	}
+}
+
+func TestRevertSubmissionNotFound(t *testing.T) {
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []ChangeInfo{})
+	})
+
+	_, _, _, err := client.Changes.RevertSubmission(context.Background(), "unknown", nil)
+	if err == nil || err.Error() != "no changes found for submission unknown" {
+		t.Errorf("RevertSubmission() error = %v", err)
+	}
+}
+
+func TestGetPureRevert(t *testing.T) {
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/changes/42/pure_revert" || r.URL.Query().Get("o") != "a1b2c3" {
+			t.Errorf("request = %s, want /changes/42/pure_revert?o=a1b2c3", r.URL)
+		}
+		writeJSON(w, PureRevertInfo{IsPureRevert: true})
+	})
+
+	info, _, err := NewChange(client, "42").GetPureRevert(context.Background(), &PureRevertOptions{ClaimedOriginal: "a1b2c3"})
+	if err != nil {
+		t.Fatalf("GetPureRevert() error = %v", err)
+	}
+	if !info.IsPureRevert {
+		t.Error("IsPureRevert = false, want true")
+	}
+}
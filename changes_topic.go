@@ -0,0 +1,204 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Topic groups the changes, possibly spread over several projects and branches, that share a topic.
+type Topic struct {
+	gerrit *Gerrit
+	Base   string
+}
+
+// TopicChangesOptions specifies the parameters to Topic.ListChanges.
+type TopicChangesOptions struct {
+	// Status limits the changes to the given status, e.g. "open", "merged" or "abandoned". All changes are listed if empty.
+	Status string
+
+	ChangeOptions
+}
+
+// TopicSubmittability describes whether the changes of a topic can be submitted together.
+type TopicSubmittability struct {
+	// Changes are the open changes of the topic.
+	Changes []ChangeInfo
+
+	// Blocking are the open changes of the topic that are not submittable.
+	Blocking []ChangeInfo
+
+	// NonVisibleChanges is the number of changes that would be submitted together with the topic but are not visible to the caller.
+	NonVisibleChanges int
+
+	// Submittable is true when every open change of the topic is submittable and all of them are visible.
+	Submittable bool
+}
+
+func NewTopic(gerrit *Gerrit, topic string) *Topic {
+	return &Topic{gerrit: gerrit, Base: topic}
+}
+
+// Topic returns the topic with the given name.
+func (s *ChangeService) Topic(topic string) *Topic {
+	return NewTopic(s.gerrit, topic)
+}
+
+// ListChanges lists the changes of the topic across all projects, following pagination until all changes are retrieved.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/user-search.html#topic
+func (t *Topic) ListChanges(ctx context.Context, opt *TopicChangesOptions) ([]ChangeInfo, *http.Response, error) {
+	query := fmt.Sprintf("topic:%s", strconv.Quote(t.Base))
+	option := QueryChangeOptions{}
+	if opt != nil {
+		if opt.Status != "" {
+			query += " status:" + opt.Status
+		}
+		option.ChangeOptions = opt.ChangeOptions
+	}
+	option.Query = []string{query}
+
+	return t.gerrit.Changes.queryAll(ctx, &option)
+}
+
+// GetSubmittability reports whether the open changes of the topic can be submitted together.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#submitted_together
+func (t *Topic) GetSubmittability(ctx context.Context) (*TopicSubmittability, *http.Response, error) {
+	changes, resp, err := t.ListChanges(ctx, &TopicChangesOptions{
+		Status:        "open",
+		ChangeOptions: ChangeOptions{AdditionalFields: []string{"SUBMITTABLE"}},
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	result := &TopicSubmittability{Changes: changes}
+	if len(changes) == 0 {
+		return result, resp, nil
+	}
+
+	for _, change := range changes {
+		if !change.Submittable {
+			result.Blocking = append(result.Blocking, change)
+		}
+	}
+
+	together, resp, err := NewChange(t.gerrit, changes[0].ID).SubmittedTogether(ctx, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+	result.NonVisibleChanges = together.NonVisibleChanges
+	result.Submittable = len(result.Blocking) == 0 && result.NonVisibleChanges == 0
+
+	return result, resp, nil
+}
+
+// Submit submits all open changes of the topic atomically.
+//
+// Gerrit submits a whole topic at once when change.submitWholeTopic is enabled on the server.
+// Submit verifies that submitting one change of the topic submits all others too, so that
+// the topic is never merged partially, and then submits that change.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#submit-change
+func (t *Topic) Submit(ctx context.Context, input *SubmitInput) (*ChangeInfo, *http.Response, error) {
+	changes, resp, err := t.ListChanges(ctx, &TopicChangesOptions{Status: "open"})
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(changes) == 0 {
+		return nil, resp, fmt.Errorf("topic %q has no open changes", t.Base)
+	}
+
+	change := NewChange(t.gerrit, changes[0].ID)
+	together, resp, err := change.SubmittedTogether(ctx, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+	if together.NonVisibleChanges > 0 {
+		return nil, resp, fmt.Errorf("topic %q: %d changes submitted together are not visible to the caller", t.Base, together.NonVisibleChanges)
+	}
+
+	included := make(map[string]bool)
+	for _, c := range together.Changes {
+		included[c.ID] = true
+	}
+	var missing []string
+	for _, c := range changes[1:] {
+		if !included[c.ID] {
+			missing = append(missing, strconv.Itoa(c.Number))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, resp, fmt.Errorf("topic %q cannot be submitted atomically, changes %s would not be submitted (is change.submitWholeTopic enabled?)", t.Base, strings.Join(missing, ", "))
+	}
+
+	return change.Submit(ctx, input)
+}
+
+// Abandon abandons every open change of the topic.
+// A failure to abandon one change does not prevent abandoning the others.
+func (t *Topic) Abandon(ctx context.Context, input *AbandonInput) ([]ChangeOperationResult, *http.Response, error) {
+	changes, resp, err := t.ListChanges(ctx, &TopicChangesOptions{Status: "open"})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	results := make([]ChangeOperationResult, 0, len(changes))
+	for _, c := range changes {
+		result := ChangeOperationResult{ChangeNumber: c.Number}
+		result.Change, _, result.Err = NewChange(t.gerrit, c.ID).Abandon(ctx, input)
+		results = append(results, result)
+	}
+	return results, resp, nil
+}
+
+// Rename moves every open change of the topic to another topic. An empty topic removes the changes from the topic.
+// Gerrit does not allow changing the topic of merged or abandoned changes: they keep theirs and are reported as skipped.
+// A failure to update one change does not prevent updating the others.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#set-topic
+func (t *Topic) Rename(ctx context.Context, topic string) ([]ChangeOperationResult, *http.Response, error) {
+	if topic == t.Base {
+		return nil, nil, errors.New("new topic is the same as the current one")
+	}
+
+	changes, resp, err := t.ListChanges(ctx, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	renamed := true
+	results := make([]ChangeOperationResult, 0, len(changes))
+	for i := range changes {
+		c := changes[i]
+		result := ChangeOperationResult{ChangeNumber: c.Number}
+		if c.Status != "NEW" {
+			result.Skipped, result.Reason = true, "change is "+strings.ToLower(c.Status)
+			results = append(results, result)
+			continue
+		}
+
+		change := NewChange(t.gerrit, c.ID)
+		if topic == "" {
+			_, _, result.Err = change.DeleteTopic(ctx)
+		} else {
+			_, _, result.Err = change.SetTopic(ctx, &TopicInput{Topic: topic})
+		}
+		if result.Err == nil {
+			c.Topic = topic
+			result.Change = &c
+		} else {
+			renamed = false
+		}
+		results = append(results, result)
+	}
+
+	if renamed {
+		t.Base = topic
+	}
+	return results, resp, nil
+}
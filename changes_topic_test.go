@@ -0,0 +1,165 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// topicTestServer serves the changes of a topic, in pages of two, and answers the other requests with handler.
+func topicTestServer(t *testing.T, changes []ChangeInfo, handler http.HandlerFunc) *Gerrit {
+	return newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/changes/" {
+			handler(w, r)
+			return
+		}
+		var matching []ChangeInfo
+		for _, c := range changes {
+			if !strings.Contains(r.URL.Query().Get("q"), "status:open") || c.Status == "NEW" {
+				matching = append(matching, c)
+			}
+		}
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		page := append([]ChangeInfo(nil), matching[start:]...)
+		if len(page) > 2 {
+			page = page[:2]
+			page[1].MoreChanges = true
+		}
+		writeJSON(w, page)
+	})
+}
+
+var topicChanges = []ChangeInfo{
+	{ID: "p~1", Number: 1, Status: "NEW", Topic: "release"},
+	{ID: "p~2", Number: 2, Status: "MERGED", Topic: "release"},
+	{ID: "p~3", Number: 3, Status: "NEW", Topic: "release"},
+	{ID: "p~4", Number: 4, Status: "ABANDONED", Topic: "release"},
+	{ID: "p~5", Number: 5, Status: "NEW", Topic: "release"},
+}
+
+func TestTopicListChanges(t *testing.T) {
+	var queries []string
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("q")+" start="+r.URL.Query().Get("start"))
+		if r.URL.Query().Get("start") == "" {
+			writeJSON(w, []ChangeInfo{{Number: 1}, {Number: 2, MoreChanges: true}})
+			return
+		}
+		writeJSON(w, []ChangeInfo{{Number: 3}})
+	})
+
+	changes, _, err := NewTopic(client, "release 1.0").ListChanges(context.Background(), &TopicChangesOptions{Status: "open"})
+	if err != nil {
+		t.Fatalf("ListChanges() error = %v", err)
+	}
+	if len(changes) != 3 {
+		t.Errorf("ListChanges() returned %d changes, want 3", len(changes))
+	}
+	want := []string{`topic:"release 1.0" status:open start=`, `topic:"release 1.0" status:open start=2`}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("queries = %q, want %q", queries, want)
+	}
+}
+
+func TestTopicRename(t *testing.T) {
+	var renamed []string
+	client := topicTestServer(t, topicChanges, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.HasSuffix(r.URL.Path, "/topic") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+		if r.URL.Path == "/changes/p~5/topic" {
+			http.Error(w, "restricted", http.StatusForbidden)
+			return
+		}
+		renamed = append(renamed, r.URL.Path)
+		writeJSON(w, "release-2")
+	})
+
+	topic := NewTopic(client, "release")
+	results, _, err := topic.Rename(context.Background(), "release-2")
+	if err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+
+	var got []string
+	for _, result := range results {
+		switch {
+		case result.Err != nil:
+			got = append(got, "error")
+		case result.Skipped:
+			got = append(got, result.Reason)
+		default:
+			got = append(got, result.Change.Topic)
+		}
+	}
+	want := []string{"release-2", "change is merged", "release-2", "change is abandoned", "error"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Rename() = %q, want %q", got, want)
+	}
+	if want := []string{"/changes/p~1/topic", "/changes/p~3/topic"}; !reflect.DeepEqual(renamed, want) {
+		t.Errorf("renamed = %q, want %q", renamed, want)
+	}
+	if topic.Base != "release" {
+		t.Errorf("Base = %q, want it unchanged after a failure", topic.Base)
+	}
+
+	if _, _, err := topic.Rename(context.Background(), "release"); err == nil {
+		t.Error("Rename() to the same topic succeeded, want an error")
+	}
+}
+
+func TestTopicSubmit(t *testing.T) {
+	tests := []struct {
+		name     string
+		together []ChangeInfo
+		hidden   int
+		wantErr  string
+	}{
+		{
+			name:     "whole topic",
+			together: []ChangeInfo{{ID: "p~1"}, {ID: "p~3"}, {ID: "p~5"}},
+		},
+		{
+			name:     "partial topic",
+			together: []ChangeInfo{{ID: "p~1"}, {ID: "p~3"}},
+			wantErr:  `topic "release" cannot be submitted atomically, changes 5 would not be submitted (is change.submitWholeTopic enabled?)`,
+		},
+		{
+			name:     "hidden changes",
+			together: []ChangeInfo{{ID: "p~1"}, {ID: "p~3"}, {ID: "p~5"}},
+			hidden:   1,
+			wantErr:  `topic "release": 1 changes submitted together are not visible to the caller`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			submitted := false
+			client := topicTestServer(t, topicChanges, func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method + " " + r.URL.Path {
+				case "GET /changes/p~1/submitted_together":
+					writeJSON(w, SubmittedTogetherInfo{Changes: tt.together, NonVisibleChanges: tt.hidden})
+				case "POST /changes/p~1/submit":
+					submitted = true
+					writeJSON(w, ChangeInfo{Status: "MERGED"})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			})
+
+			_, _, err := NewTopic(client, "release").Submit(context.Background(), nil)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Submit() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Submit() error = %v, want %q", err, tt.wantErr)
+			}
+			if submitted != (tt.wantErr == "") {
+				t.Errorf("submitted = %v, want %v", submitted, tt.wantErr == "")
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// VoteOutcome describes what happened to a single label vote.
+type VoteOutcome string
+
+const (
+	// VoteApplied means the vote was sent and recorded by Gerrit.
+	VoteApplied VoteOutcome = "APPLIED"
+	// VoteDropped means the vote was valid for the label but not cast, e.g. because the caller is not permitted to cast it.
+	VoteDropped VoteOutcome = "DROPPED"
+	// VoteRejected means the vote names an unknown label or a value that the label does not define.
+	VoteRejected VoteOutcome = "REJECTED"
+)
+
+// VoteInput contains the votes to cast on a revision.
+type VoteInput struct {
+	// Labels maps label names to the values to vote.
+	Labels map[string]int
+
+	// Message is an optional review message posted along with the votes.
+	Message string
+
+	// Tag is applied to the review message.
+	Tag string
+
+	// StrictLabels makes the whole vote fail when any vote is rejected or not permitted,
+	// instead of casting only the valid ones.
+	StrictLabels bool
+
+	Notify     string
+	OnBehalfOf string
+}
+
+// LabelVote describes the outcome of a single label vote.
+type LabelVote struct {
+	Label   string
+	Value   int
+	Outcome VoteOutcome
+	Reason  string
+}
+
+// VoteResult reports which votes of a VoteInput were applied, dropped or rejected.
+type VoteResult struct {
+	Applied  []LabelVote
+	Dropped  []LabelVote
+	Rejected []LabelVote
+
+	// Review is the result of the review sent to Gerrit; nil if no vote was sent.
+	Review *ReviewResult
+}
+
+// Vote casts label votes on a revision after validating them against the labels of the change.
+//
+// Each vote is checked against LabelInfo.Values of the change, which lists the values the label defines,
+// and against PermittedLabels, which lists the values the caller may cast.
+// Votes on unknown labels or undefined values are rejected; valid votes the caller may not cast are dropped.
+// Gerrit only computes PermittedLabels for the current patch set, so votes on an older revision are not checked
+// against them and are left to the server.
+// With StrictLabels, any rejected or dropped vote makes Vote return an error without sending anything.
+//
+// The remaining votes are sent with SetRevisionReview and reported as applied once ReviewResult confirms them.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#set-review
+func (c *Change) Vote(ctx context.Context, revisionID string, input *VoteInput) (*VoteResult, *http.Response, error) {
+	if input == nil || len(input.Labels) == 0 {
+		return nil, nil, errors.New("no votes to cast")
+	}
+	if revisionID == "" {
+		revisionID = "current"
+	}
+
+	info, resp, err := c.GetDetail(ctx, &ChangeOptions{AdditionalFields: []string{"DETAILED_LABELS", "CURRENT_REVISION"}})
+	if err != nil {
+		return nil, resp, err
+	}
+	current := isCurrentRevision(info, revisionID)
+
+	result := new(VoteResult)
+	labels := make(map[string]int)
+
+	names := make([]string, 0, len(input.Labels))
+	for name := range input.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		vote := LabelVote{Label: name, Value: input.Labels[name]}
+
+		label, ok := info.Labels[name]
+		switch {
+		case !ok:
+			vote.Outcome, vote.Reason = VoteRejected, "label is not defined on the change"
+		case !labelDefinesValue(label.Values, vote.Value):
+			vote.Outcome, vote.Reason = VoteRejected, fmt.Sprintf("label does not define the value %s", FormatLabelValue(vote.Value))
+		case current && !labelPermitsValue(info.PermittedLabels[name], vote.Value):
+			vote.Outcome, vote.Reason = VoteDropped, "caller is not permitted to cast this vote"
+		default:
+			labels[name] = vote.Value
+			continue
+		}
+
+		if vote.Outcome == VoteRejected {
+			result.Rejected = append(result.Rejected, vote)
+		} else {
+			result.Dropped = append(result.Dropped, vote)
+		}
+	}
+
+	if input.StrictLabels && (len(result.Rejected) > 0 || len(result.Dropped) > 0) {
+		invalid := make([]string, 0, len(result.Rejected)+len(result.Dropped))
+		for _, vote := range append(append([]LabelVote(nil), result.Rejected...), result.Dropped...) {
+			invalid = append(invalid, fmt.Sprintf("%s%s (%s)", vote.Label, FormatLabelValue(vote.Value), vote.Reason))
+		}
+		return result, resp, fmt.Errorf("invalid votes: %s", strings.Join(invalid, ", "))
+	}
+
+	if len(labels) == 0 {
+		return result, resp, nil
+	}
+
+	review := &ReviewInput{
+		Message:      input.Message,
+		Tag:          input.Tag,
+		Labels:       labels,
+		StrictLabels: input.StrictLabels,
+		Notify:       input.Notify,
+		OnBehalfOf:   input.OnBehalfOf,
+	}
+	result.Review, resp, err = c.SetRevisionReview(ctx, revisionID, review)
+	if err != nil {
+		return result, resp, err
+	}
+
+	for _, name := range names {
+		value, sent := labels[name]
+		if !sent {
+			continue
+		}
+		vote := LabelVote{Label: name, Value: value}
+		if applied, ok := result.Review.Labels[name]; ok && applied == value {
+			vote.Outcome = VoteApplied
+			result.Applied = append(result.Applied, vote)
+		} else {
+			vote.Outcome, vote.Reason = VoteDropped, "vote was not recorded by the server"
+			result.Dropped = append(result.Dropped, vote)
+		}
+	}
+
+	return result, resp, nil
+}
+
+// ParseLabelValue parses a label value as used in LabelInfo.Values and PermittedLabels, e.g. "+1", " 0" or "-2".
+func ParseLabelValue(s string) (int, error) {
+	return strconv.Atoi(strings.TrimSpace(s))
+}
+
+// FormatLabelValue formats a label value the way Gerrit does, e.g. "+1", "0" or "-2".
+func FormatLabelValue(value int) string {
+	if value > 0 {
+		return "+" + strconv.Itoa(value)
+	}
+	return strconv.Itoa(value)
+}
+
+// isCurrentRevision reports whether a revision ID, as accepted by the revision endpoints, names the current patch set
+// of a change. The change must have been retrieved with CURRENT_REVISION.
+func isCurrentRevision(info *ChangeInfo, revisionID string) bool {
+	if revisionID == "current" || info.CurrentRevision == "" {
+		return true
+	}
+	if number, err := strconv.Atoi(revisionID); err == nil {
+		return number == info.Revisions[info.CurrentRevision].Number
+	}
+	return strings.HasPrefix(info.CurrentRevision, revisionID)
+}
+
+// labelDefinesValue reports whether value is one of the values of a LabelInfo.
+func labelDefinesValue(values map[string]string, value int) bool {
+	for s := range values {
+		if v, err := ParseLabelValue(s); err == nil && v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// labelPermitsValue reports whether value is one of the permitted values of a label.
+func labelPermitsValue(permitted []string, value int) bool {
+	for _, s := range permitted {
+		if v, err := ParseLabelValue(s); err == nil && v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestParseLabelValue(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"+1", 1, false},
+		{" 0", 0, false},
+		{"-2", -2, false},
+		{"+2 ", 2, false},
+		{"approved", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLabelValue(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseLabelValue(%q) = %d, %v, want %d, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	for value, want := range map[int]string{2: "+2", 0: "0", -1: "-1"} {
+		if got := FormatLabelValue(value); got != want {
+			t.Errorf("FormatLabelValue(%d) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestIsCurrentRevision(t *testing.T) {
+	info := &ChangeInfo{
+		CurrentRevision: "2b3c4d5e6f",
+		Revisions: map[string]RevisionInfo{
+			"1a2b3c4d5e": {Number: 1},
+			"2b3c4d5e6f": {Number: 2},
+		},
+	}
+	tests := []struct {
+		revisionID string
+		want       bool
+	}{
+		{"current", true},
+		{"2", true},
+		{"1", false},
+		{"2b3c4d5e6f", true},
+		{"2b3c", true},
+		{"1a2b3c4d5e", false},
+	}
+	for _, tt := range tests {
+		if got := isCurrentRevision(info, tt.revisionID); got != tt.want {
+			t.Errorf("isCurrentRevision(%q) = %v, want %v", tt.revisionID, got, tt.want)
+		}
+	}
+}
+
+// voteTestChange serves a change at patch set 2, whose caller may vote up to Code-Review+1, and records the review.
+func voteTestChange(t *testing.T, review *ReviewInput) *Change {
+	values := map[string]string{"-2": "Do not submit", "-1": "No", " 0": "No score", "+1": "Yes", "+2": "Approved"}
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /changes/42/detail":
+			writeJSON(w, ChangeInfo{
+				Labels:          map[string]LabelInfo{"Code-Review": {Values: values}, "Verified": {Values: map[string]string{"-1": "Fails", " 0": "", "+1": "Verified"}}},
+				PermittedLabels: map[string][]string{"Code-Review": {"-1", " 0", "+1"}, "Verified": {"-1", " 0", "+1"}},
+				CurrentRevision: "2b3c4d5e6f",
+				Revisions:       map[string]RevisionInfo{"2b3c4d5e6f": {Number: 2}},
+			})
+		case "POST /changes/42/revisions/current/review", "POST /changes/42/revisions/1/review":
+			readJSON(t, r, review)
+			result := ReviewResult{}
+			result.Labels = review.Labels
+			writeJSON(w, result)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	return NewChange(client, "42")
+}
+
+func TestVote(t *testing.T) {
+	tests := []struct {
+		name         string
+		revisionID   string
+		labels       map[string]int
+		strict       bool
+		wantSent     map[string]int
+		wantApplied  []string
+		wantDropped  []string
+		wantRejected []string
+		wantErr      bool
+	}{
+		{
+			name:         "drops and rejects invalid votes",
+			labels:       map[string]int{"Code-Review": 2, "Verified": 1, "Library-Compliance": 1},
+			wantSent:     map[string]int{"Verified": 1},
+			wantApplied:  []string{"Verified"},
+			wantDropped:  []string{"Code-Review"},
+			wantRejected: []string{"Library-Compliance"},
+		},
+		{
+			name:         "rejects undefined values",
+			labels:       map[string]int{"Verified": 2, "Code-Review": -1},
+			wantSent:     map[string]int{"Code-Review": -1},
+			wantApplied:  []string{"Code-Review"},
+			wantRejected: []string{"Verified"},
+		},
+		{
+			name:        "strict labels send nothing",
+			labels:      map[string]int{"Code-Review": 2, "Verified": 1},
+			strict:      true,
+			wantDropped: []string{"Code-Review"},
+			wantErr:     true,
+		},
+		{
+			name:        "permitted labels only apply to the current patch set",
+			revisionID:  "1",
+			labels:      map[string]int{"Code-Review": 2},
+			wantSent:    map[string]int{"Code-Review": 2},
+			wantApplied: []string{"Code-Review"},
+		},
+	}
+
+	names := func(votes []LabelVote) []string {
+		var out []string
+		for _, vote := range votes {
+			out = append(out, vote.Label)
+		}
+		return out
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var review ReviewInput
+			change := voteTestChange(t, &review)
+
+			result, _, err := change.Vote(context.Background(), tt.revisionID, &VoteInput{Labels: tt.labels, StrictLabels: tt.strict})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Vote() error = %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(review.Labels, tt.wantSent) {
+				t.Errorf("sent labels = %v, want %v", review.Labels, tt.wantSent)
+			}
+			if got := names(result.Applied); !reflect.DeepEqual(got, tt.wantApplied) {
+				t.Errorf("Applied = %v, want %v", got, tt.wantApplied)
+			}
+			if got := names(result.Dropped); !reflect.DeepEqual(got, tt.wantDropped) {
+				t.Errorf("Dropped = %v, want %v", got, tt.wantDropped)
+			}
+			if got := names(result.Rejected); !reflect.DeepEqual(got, tt.wantRejected) {
+				t.Errorf("Rejected = %v, want %v", got, tt.wantRejected)
+			}
+		})
+	}
+}
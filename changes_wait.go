@@ -0,0 +1,187 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ChangePredicate is a condition on a change that WaitFor waits for.
+type ChangePredicate struct {
+	// Name describes the condition in error messages.
+	Name string
+
+	// AdditionalFields are the o= options the change must be retrieved with for Match to work.
+	AdditionalFields []string
+
+	// Match reports whether the condition holds for the change.
+	Match func(info *ChangeInfo) bool
+}
+
+// WaitOptions specifies how WaitFor polls a change.
+type WaitOptions struct {
+	// Interval is the delay before the first re-poll. Defaults to 5 seconds.
+	Interval time.Duration
+
+	// MaxInterval caps the delay between polls as it backs off. Defaults to 1 minute.
+	MaxInterval time.Duration
+
+	// Multiplier is the factor the delay grows by after each poll that does not satisfy the predicate. Defaults to 1.5.
+	Multiplier float64
+
+	// Timeout bounds the total time spent waiting, in addition to the deadline of the context. Zero means no timeout.
+	Timeout time.Duration
+
+	// AdditionalFields are retrieved in addition to the ones required by the predicate.
+	AdditionalFields []string
+}
+
+// ErrWaitTimeout is returned by WaitFor when the change did not satisfy the predicate within WaitOptions.Timeout.
+var ErrWaitTimeout = errors.New("timed out waiting for change")
+
+// ChangeMerged is satisfied once the change is merged.
+func ChangeMerged() ChangePredicate {
+	return ChangePredicate{
+		Name:  "merged",
+		Match: func(info *ChangeInfo) bool { return info.Status == "MERGED" },
+	}
+}
+
+// ChangeAbandoned is satisfied once the change is abandoned.
+func ChangeAbandoned() ChangePredicate {
+	return ChangePredicate{
+		Name:  "abandoned",
+		Match: func(info *ChangeInfo) bool { return info.Status == "ABANDONED" },
+	}
+}
+
+// ChangeLabelAt is satisfied once any reviewer votes exactly value on label, e.g. ChangeLabelAt("Verified", 1).
+func ChangeLabelAt(label string, value int) ChangePredicate {
+	return ChangePredicate{
+		Name:             fmt.Sprintf("%s%s", label, FormatLabelValue(value)),
+		AdditionalFields: []string{"DETAILED_LABELS"},
+		Match: func(info *ChangeInfo) bool {
+			for _, approval := range info.Labels[label].All {
+				if approval.Value == value {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// ChangeNewRevision is satisfied once the change has a patch set newer than patchSet.
+func ChangeNewRevision(patchSet int) ChangePredicate {
+	return ChangePredicate{
+		Name:             fmt.Sprintf("patch set after %d", patchSet),
+		AdditionalFields: []string{"CURRENT_REVISION"},
+		Match: func(info *ChangeInfo) bool {
+			revision, ok := info.Revisions[info.CurrentRevision]
+			return ok && revision.Number > patchSet
+		},
+	}
+}
+
+// ChangeSubmittable is satisfied once the change can be submitted.
+func ChangeSubmittable() ChangePredicate {
+	return ChangePredicate{
+		Name:             "submittable",
+		AdditionalFields: []string{"SUBMITTABLE"},
+		Match:            func(info *ChangeInfo) bool { return info.Submittable },
+	}
+}
+
+// WaitFor polls the change until predicate matches, backing off between polls.
+//
+// Polls are conditional requests: the ETag of the previous response is sent along,
+// so that an unchanged change costs the server no more than a "304 Not Modified".
+//
+// On success the matching ChangeInfo is returned and stored in c.Raw.
+// When opt.Timeout expires, the last observed ChangeInfo is returned together with an error wrapping ErrWaitTimeout.
+// When ctx is cancelled or its deadline passes, the last observed ChangeInfo is returned together with ctx.Err().
+func (c *Change) WaitFor(ctx context.Context, predicate ChangePredicate, opt *WaitOptions) (*ChangeInfo, *http.Response, error) {
+	if predicate.Match == nil {
+		return nil, nil, errors.New("wait for change: predicate has no Match function")
+	}
+
+	o := WaitOptions{Interval: 5 * time.Second, MaxInterval: time.Minute, Multiplier: 1.5}
+	if opt != nil {
+		if opt.Interval > 0 {
+			o.Interval = opt.Interval
+		}
+		if opt.MaxInterval > 0 {
+			o.MaxInterval = opt.MaxInterval
+		}
+		if opt.Multiplier >= 1 {
+			o.Multiplier = opt.Multiplier
+		}
+		o.Timeout = opt.Timeout
+		o.AdditionalFields = opt.AdditionalFields
+	}
+	parent := ctx
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+	// stopped tells the expiry of opt.Timeout apart from the caller giving up on ctx.
+	stopped := func() error {
+		if err := parent.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w %s to be %s after %s", ErrWaitTimeout, c.Base, predicate.Name, o.Timeout)
+	}
+
+	fields := &ChangeOptions{AdditionalFields: append(append([]string(nil), predicate.AdditionalFields...), o.AdditionalFields...)}
+	u := fmt.Sprintf("changes/%s", c.Base)
+
+	var (
+		last  *ChangeInfo
+		resp  *http.Response
+		etag  string
+		delay = o.Interval
+	)
+	for {
+		req, err := c.gerrit.Requester.NewRequest(ctx, "GET", u, fields)
+		if err != nil {
+			return last, nil, err
+		}
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+
+		v := new(ChangeInfo)
+		resp, err = c.gerrit.Requester.Do(req, v)
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, resp, stopped()
+			}
+			return last, resp, err
+		}
+
+		if resp.StatusCode != http.StatusNotModified {
+			last = v
+			c.Raw = v
+			etag = resp.Header.Get("ETag")
+			if predicate.Match(last) {
+				return last, resp, nil
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, resp, stopped()
+		case <-timer.C:
+		}
+
+		delay = time.Duration(float64(delay) * o.Multiplier)
+		if delay > o.MaxInterval {
+			delay = o.MaxInterval
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestChangePredicates(t *testing.T) {
+	info := &ChangeInfo{
+		Status:          "NEW",
+		Submittable:     true,
+		CurrentRevision: "2b3c4d5e6f",
+		Revisions:       map[string]RevisionInfo{"2b3c4d5e6f": {Number: 2}},
+		Labels:          map[string]LabelInfo{"Verified": {All: []ApprovalInfo{{Value: 0}, {Value: 1}}}},
+	}
+	tests := []struct {
+		predicate ChangePredicate
+		want      bool
+	}{
+		{ChangeMerged(), false},
+		{ChangeAbandoned(), false},
+		{ChangeSubmittable(), true},
+		{ChangeLabelAt("Verified", 1), true},
+		{ChangeLabelAt("Verified", -1), false},
+		{ChangeLabelAt("Code-Review", 0), false},
+		{ChangeNewRevision(1), true},
+		{ChangeNewRevision(2), false},
+	}
+	for _, tt := range tests {
+		if got := tt.predicate.Match(info); got != tt.want {
+			t.Errorf("%s: Match() = %v, want %v", tt.predicate.Name, got, tt.want)
+		}
+	}
+	if name := ChangeLabelAt("Verified", 1).Name; name != "Verified+1" {
+		t.Errorf("ChangeLabelAt().Name = %q, want Verified+1", name)
+	}
+}
+
+func TestWaitFor(t *testing.T) {
+	var polls int32
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&polls, 1)
+		switch {
+		case n == 1:
+			w.Header().Set("ETag", `"1"`)
+			writeJSON(w, ChangeInfo{Number: 42, Status: "NEW"})
+		case n == 2:
+			if r.Header.Get("If-None-Match") != `"1"` {
+				t.Errorf("If-None-Match = %q, want the ETag of the previous poll", r.Header.Get("If-None-Match"))
+			}
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			writeJSON(w, ChangeInfo{Number: 42, Status: "MERGED"})
+		}
+	})
+
+	change := NewChange(client, "42")
+	info, _, err := change.WaitFor(context.Background(), ChangeMerged(), &WaitOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WaitFor() error = %v", err)
+	}
+	if info.Status != "MERGED" || change.Raw.Status != "MERGED" || polls != 3 {
+		t.Errorf("WaitFor() = %s after %d polls, want MERGED after 3", info.Status, polls)
+	}
+}
+
+func TestWaitForStopped(t *testing.T) {
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, ChangeInfo{Number: 42, Status: "NEW"})
+	})
+	change := NewChange(client, "42")
+
+	tests := []struct {
+		name    string
+		ctx     func() (context.Context, context.CancelFunc)
+		timeout time.Duration
+		want    error
+		notWant error
+	}{
+		{
+			name:    "own timeout",
+			ctx:     func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			timeout: 20 * time.Millisecond,
+			want:    ErrWaitTimeout,
+			notWant: context.DeadlineExceeded,
+		},
+		{
+			name: "cancelled context",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(20*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			timeout: time.Hour,
+			want:    context.Canceled,
+			notWant: ErrWaitTimeout,
+		},
+		{
+			name: "context deadline",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 20*time.Millisecond)
+			},
+			timeout: time.Hour,
+			want:    context.DeadlineExceeded,
+			notWant: ErrWaitTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			info, _, err := change.WaitFor(ctx, ChangeMerged(), &WaitOptions{Interval: time.Millisecond, Timeout: tt.timeout})
+			if !errors.Is(err, tt.want) || errors.Is(err, tt.notWant) {
+				t.Errorf("WaitFor() error = %v, want %v", err, tt.want)
+			}
+			if info == nil || info.Status != "NEW" {
+				t.Errorf("WaitFor() = %+v, want the last observed change", info)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package gerrit
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 // ConfigService contains Config related REST endpoints
@@ -398,6 +400,37 @@ func (s *ConfigService) GetTask(ctx context.Context, taskID string) (*TaskInfo,
 	return v, resp, nil
 }
 
+// WaitForTask polls a task of the background work queue every interval, 5 seconds by default, until it is done.
+// A task is done once it has left the queue; the last observed TaskInfo is returned.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-config.html#get-task
+func (s *ConfigService) WaitForTask(ctx context.Context, taskID string, interval time.Duration) (*TaskInfo, *http.Response, error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	var last *TaskInfo
+	for {
+		task, resp, err := s.GetTask(ctx, taskID)
+		if err != nil {
+			var e *ErrorResponse
+			if errors.As(err, &e) && e.Response.StatusCode == http.StatusNotFound {
+				return last, resp, nil
+			}
+			return last, resp, err
+		}
+		last = task
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, resp, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
 // GetTopMenus returns the list of additional top menu entries.
 //
 // Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-config.html#get-top-menus
@@ -0,0 +1,291 @@
+package gerrit
+
+import (
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Footer is a trailer line of a commit message, e.g. "Change-Id: I8473b95934b5732ac55d26311a706c9c2bde9940".
+type Footer struct {
+	Key   string
+	Value string
+}
+
+// String formats the footer as a line of a commit message.
+func (f Footer) String() string {
+	return f.Key + ": " + f.Value
+}
+
+// Well-known footers of Gerrit commit messages.
+const (
+	FooterChangeID     = "Change-Id"
+	FooterSignedOffBy  = "Signed-off-by"
+	FooterReviewedOn   = "Reviewed-on"
+	FooterReviewedBy   = "Reviewed-by"
+	FooterTestedBy     = "Tested-by"
+	FooterBug          = "Bug"
+	FooterDependsOn    = "Depends-On"
+	FooterCherryPickOf = "Cherry-pick-of"
+)
+
+// ChangeIDInput holds the commit data the commit-msg hook derives a Change-Id from.
+type ChangeIDInput struct {
+	// Tree is the SHA-1 of the tree of the commit.
+	Tree string
+
+	// Parent is the SHA-1 of the first parent of the commit, empty for a root commit.
+	Parent string
+
+	// Author and Committer are git identities as printed by "git var GIT_AUTHOR_IDENT",
+	// e.g. "Jane Doe <jane@example.com> 1700000000 +0100".
+	Author    string
+	Committer string
+
+	// Message is the commit message without a Change-Id.
+	Message string
+}
+
+var (
+	footerLine   = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*)\s*:\s*(.*)$`)
+	changeIDLine = regexp.MustCompile(`^I[0-9a-f]{40}$`)
+)
+
+// ParseFooters returns the footers of a commit message, in order.
+//
+// The footers are taken from the last paragraph of the message, following the rules of git interpret-trailers:
+// the paragraph must not be the subject, and it must consist of "Key: value" lines only,
+// or of at least 25% such lines including a Signed-off-by or "(cherry picked from commit" line.
+// Lines starting with whitespace continue the value of the preceding footer.
+func ParseFooters(message string) []Footer {
+	lines := messageLines(message)
+	start, end := footerBlock(lines)
+
+	var footers []Footer
+	for _, line := range lines[start:end] {
+		if m := footerLine.FindStringSubmatch(line); m != nil {
+			footers = append(footers, Footer{Key: m[1], Value: strings.TrimSpace(m[2])})
+			continue
+		}
+		if n := len(footers); n > 0 && isContinuationLine(line) {
+			footers[n-1].Value = strings.TrimSpace(footers[n-1].Value + " " + strings.TrimSpace(line))
+		}
+	}
+	return footers
+}
+
+// FooterValues returns the values of the footers of a commit message with the given key, compared case-insensitively.
+func FooterValues(message, key string) []string {
+	var values []string
+	for _, footer := range ParseFooters(message) {
+		if strings.EqualFold(footer.Key, key) {
+			values = append(values, footer.Value)
+		}
+	}
+	return values
+}
+
+// GetChangeID returns the Change-Id of a commit message. It is the last Change-Id footer, as Gerrit uses it.
+func GetChangeID(message string) (string, bool) {
+	values := FooterValues(message, FooterChangeID)
+	if len(values) == 0 {
+		return "", false
+	}
+	return values[len(values)-1], true
+}
+
+// AddFooter appends a footer to a commit message, starting a footer paragraph if the message has none.
+func AddFooter(message, key, value string) string {
+	lines := messageLines(message)
+	start, end := footerBlock(lines)
+	line := Footer{Key: key, Value: value}.String()
+
+	if start == end {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, line)
+	} else {
+		lines = append(lines[:end], append([]string{line}, lines[end:]...)...)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// SetFooter sets the value of a footer of a commit message.
+// The first footer with the key is replaced and further ones are removed; the footer is added if the message has none.
+func SetFooter(message, key, value string) string {
+	lines := messageLines(message)
+	start, end := footerBlock(lines)
+
+	var (
+		out   []string
+		found bool
+		skip  bool
+	)
+	for i, line := range lines {
+		if i < start || i >= end {
+			out = append(out, line)
+			continue
+		}
+		if skip && isContinuationLine(line) {
+			continue
+		}
+		skip = false
+		if m := footerLine.FindStringSubmatch(line); m != nil && strings.EqualFold(m[1], key) {
+			skip = true
+			if found {
+				continue
+			}
+			found = true
+			line = Footer{Key: m[1], Value: value}.String()
+		}
+		out = append(out, line)
+	}
+	if !found {
+		return AddFooter(message, key, value)
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+// RemoveFooter removes all footers with the given key from a commit message.
+func RemoveFooter(message, key string) string {
+	lines := messageLines(message)
+	start, end := footerBlock(lines)
+
+	var (
+		out  []string
+		skip bool
+	)
+	for i, line := range lines {
+		if i < start || i >= end {
+			out = append(out, line)
+			continue
+		}
+		if skip && isContinuationLine(line) {
+			continue
+		}
+		skip = false
+		if m := footerLine.FindStringSubmatch(line); m != nil && strings.EqualFold(m[1], key) {
+			skip = true
+			continue
+		}
+		out = append(out, line)
+	}
+	for len(out) > 0 && strings.TrimSpace(out[len(out)-1]) == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+// GenerateChangeID computes a Change-Id the way the classic Gerrit commit-msg hook does:
+// the SHA-1 of a git blob holding the tree, parent, author, committer and cleaned up message of the commit.
+// Like the hook, the message is hashed without its comments, Signed-off-by lines and trailing newline.
+func GenerateChangeID(input ChangeIDInput) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "tree %s\n", input.Tree)
+	if input.Parent != "" {
+		fmt.Fprintf(&b, "parent %s\n", input.Parent)
+	}
+	fmt.Fprintf(&b, "author %s\n", input.Author)
+	fmt.Fprintf(&b, "committer %s\n", input.Committer)
+	b.WriteString("\n")
+	b.WriteString(cleanCommitMessage(input.Message))
+
+	data := b.String()
+	sum := sha1.Sum([]byte(fmt.Sprintf("blob %d\x00%s", len(data), data)))
+	return fmt.Sprintf("I%x", sum)
+}
+
+// ValidateChangeID checks that a commit message has exactly one well-formed Change-Id, and that it is the one of the change.
+// A nil info only checks the Change-Id footer itself.
+func ValidateChangeID(message string, info *ChangeInfo) error {
+	values := FooterValues(message, FooterChangeID)
+	switch {
+	case len(values) == 0:
+		return errors.New("commit message has no Change-Id footer")
+	case len(values) > 1:
+		return fmt.Errorf("commit message has %d Change-Id footers", len(values))
+	case !changeIDLine.MatchString(values[0]):
+		return fmt.Errorf("invalid Change-Id %q: must be \"I\" followed by 40 hexadecimal digits", values[0])
+	case info != nil && values[0] != info.ChangeID:
+		return fmt.Errorf("commit message has Change-Id %s, but change %d has Change-Id %s", values[0], info.Number, info.ChangeID)
+	}
+	return nil
+}
+
+// messageLines splits a commit message into lines, without trailing blank lines.
+func messageLines(message string) []string {
+	lines := strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// footerBlock returns the range of lines holding the footers of a message; start == end if it has none.
+func footerBlock(lines []string) (int, int) {
+	end := len(lines)
+	start := end
+	for start > 0 && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+	if start == 0 {
+		// The only paragraph starts with the subject, which is never a footer.
+		return end, end
+	}
+
+	footers, others := 0, 0
+	generated := false
+	for _, line := range lines[start:end] {
+		switch {
+		case strings.HasPrefix(line, "#"):
+		case footerLine.MatchString(line):
+			footers++
+			if strings.HasPrefix(line, FooterSignedOffBy+":") {
+				generated = true
+			}
+		case strings.HasPrefix(line, "(cherry picked from commit "):
+			footers++
+			generated = true
+		case isContinuationLine(line) && footers > 0:
+		default:
+			others++
+		}
+	}
+	if footers == 0 || (others > 0 && !(generated && footers*3 >= others)) {
+		return end, end
+	}
+	return start, end
+}
+
+func isContinuationLine(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+}
+
+// cleanCommitMessage cleans up a message like the commit-msg hook does before hashing it: comments, Signed-off-by lines
+// and the diff of "git commit -v" are dropped, the rest goes through "git stripspace", and the shell drops the final newline.
+func cleanCommitMessage(message string) string {
+	var out []string
+	blank := false
+	for _, line := range strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			break
+		}
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, FooterSignedOffBy+":") {
+			continue
+		}
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			blank = len(out) > 0
+			continue
+		}
+		if blank {
+			out = append(out, "")
+			blank = false
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
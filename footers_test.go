@@ -0,0 +1,209 @@
+package gerrit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFooters(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []Footer
+	}{
+		{
+			name:    "footer paragraph",
+			message: "Fix the build\n\nDetails.\n\nBug: 123\nChange-Id: I0123456789abcdef0123456789abcdef01234567\n",
+			want:    []Footer{{"Bug", "123"}, {"Change-Id", "I0123456789abcdef0123456789abcdef01234567"}},
+		},
+		{
+			name:    "continuation lines",
+			message: "Fix the build\n\nCo-authored-by: Jane Doe\n  <jane@example.com>\nTested-by: CI\n",
+			want:    []Footer{{"Co-authored-by", "Jane Doe <jane@example.com>"}, {"Tested-by", "CI"}},
+		},
+		{
+			name:    "only the last paragraph",
+			message: "Fix the build\n\nBug: 123\n\nSee: the discussion\nbelow the fold.\n",
+			want:    nil,
+		},
+		{
+			name:    "subject is never a footer",
+			message: "Bug: 123\n",
+			want:    nil,
+		},
+		{
+			name:    "mostly free text with Signed-off-by",
+			message: "Fix the build\n\nSigned-off-by: Jane Doe <jane@example.com>\nsome text\nmore text\n",
+			want:    []Footer{{"Signed-off-by", "Jane Doe <jane@example.com>"}},
+		},
+		{
+			name:    "mostly free text without Signed-off-by",
+			message: "Fix the build\n\nBug: 123\nsome text\nmore text\n",
+			want:    nil,
+		},
+		{
+			name:    "trailing blank lines and CRLF",
+			message: "Fix the build\r\n\r\nBug: 123\r\n\r\n\r\n",
+			want:    []Footer{{"Bug", "123"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseFooters(tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFooters() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetChangeID(t *testing.T) {
+	message := "Fix\n\nChange-Id: I1111111111111111111111111111111111111111\nchange-id: I2222222222222222222222222222222222222222\n"
+	if id, ok := GetChangeID(message); !ok || id != "I2222222222222222222222222222222222222222" {
+		t.Errorf("GetChangeID() = %q, %v, want the last Change-Id", id, ok)
+	}
+	if _, ok := GetChangeID("Fix\n\nChange-Id in the body\n"); ok {
+		t.Error("GetChangeID() of a message without footers succeeded")
+	}
+}
+
+func TestFooterEditing(t *testing.T) {
+	const withFooters = "Fix the build\n\nDetails.\n\nBug: 123\nReviewed-by: Jane\n  Doe\nBug: 456\n"
+	tests := []struct {
+		name string
+		edit func(string) string
+		in   string
+		want string
+	}{
+		{
+			name: "add to the footer paragraph",
+			edit: func(m string) string { return AddFooter(m, FooterTestedBy, "CI") },
+			in:   withFooters,
+			want: "Fix the build\n\nDetails.\n\nBug: 123\nReviewed-by: Jane\n  Doe\nBug: 456\nTested-by: CI\n",
+		},
+		{
+			name: "add a footer paragraph",
+			edit: func(m string) string { return AddFooter(m, FooterBug, "1") },
+			in:   "Fix the build\n\nBug: is fixed here.\nReally.\n\n",
+			want: "Fix the build\n\nBug: is fixed here.\nReally.\n\nBug: 1\n",
+		},
+		{
+			name: "add to an empty message",
+			edit: func(m string) string { return AddFooter(m, FooterBug, "1") },
+			in:   "",
+			want: "Bug: 1\n",
+		},
+		{
+			name: "set replaces the first and drops duplicates",
+			edit: func(m string) string { return SetFooter(m, "bug", "789") },
+			in:   withFooters,
+			want: "Fix the build\n\nDetails.\n\nBug: 789\nReviewed-by: Jane\n  Doe\n",
+		},
+		{
+			name: "set drops continuation lines",
+			edit: func(m string) string { return SetFooter(m, FooterReviewedBy, "John") },
+			in:   withFooters,
+			want: "Fix the build\n\nDetails.\n\nBug: 123\nReviewed-by: John\nBug: 456\n",
+		},
+		{
+			name: "set adds a missing footer",
+			edit: func(m string) string { return SetFooter(m, FooterTestedBy, "CI") },
+			in:   withFooters,
+			want: "Fix the build\n\nDetails.\n\nBug: 123\nReviewed-by: Jane\n  Doe\nBug: 456\nTested-by: CI\n",
+		},
+		{
+			name: "remove all occurrences",
+			edit: func(m string) string { return RemoveFooter(m, FooterBug) },
+			in:   withFooters,
+			want: "Fix the build\n\nDetails.\n\nReviewed-by: Jane\n  Doe\n",
+		},
+		{
+			name: "remove the last footer",
+			edit: func(m string) string { return RemoveFooter(m, FooterChangeID) },
+			in:   "Fix the build\n\nChange-Id: I0123456789abcdef0123456789abcdef01234567\n",
+			want: "Fix the build\n",
+		},
+		{
+			name: "remove ignores the body",
+			edit: func(m string) string { return RemoveFooter(m, FooterBug) },
+			in:   "Fix the build\n\nBug: in the body\nis explained here.\n",
+			want: "Fix the build\n\nBug: in the body\nis explained here.\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.edit(tt.in); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateChangeID(t *testing.T) {
+	const id = "I0123456789abcdef0123456789abcdef01234567"
+	tests := []struct {
+		name    string
+		message string
+		info    *ChangeInfo
+		wantErr string
+	}{
+		{"valid", "Fix\n\nChange-Id: " + id + "\n", nil, ""},
+		{"matching change", "Fix\n\nChange-Id: " + id + "\n", &ChangeInfo{Number: 42, ChangeID: id}, ""},
+		{"missing", "Fix\n", nil, "commit message has no Change-Id footer"},
+		{"duplicate", "Fix\n\nChange-Id: " + id + "\nChange-Id: " + id + "\n", nil, "commit message has 2 Change-Id footers"},
+		{"malformed", "Fix\n\nChange-Id: I0123\n", nil, `invalid Change-Id "I0123": must be "I" followed by 40 hexadecimal digits`},
+		{"upper case", "Fix\n\nChange-Id: I0123456789ABCDEF0123456789ABCDEF01234567\n", nil, `invalid Change-Id "I0123456789ABCDEF0123456789ABCDEF01234567": must be "I" followed by 40 hexadecimal digits`},
+		{
+			"other change",
+			"Fix\n\nChange-Id: " + id + "\n",
+			&ChangeInfo{Number: 42, ChangeID: "I1111111111111111111111111111111111111111"},
+			"commit message has Change-Id " + id + ", but change 42 has Change-Id I1111111111111111111111111111111111111111",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateChangeID(tt.message, tt.info)
+			if (err == nil && tt.wantErr != "") || (err != nil && err.Error() != tt.wantErr) {
+				t.Errorf("ValidateChangeID() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// The expected Change-Ids were generated by the classic commit-msg hook for the same commit data.
+func TestGenerateChangeID(t *testing.T) {
+	tests := []struct {
+		name  string
+		input ChangeIDInput
+		want  string
+	}{
+		{
+			name: "commit with comments, Signed-off-by and diff",
+			input: ChangeIDInput{
+				Tree:      "f4b354863caa9cea99b95422c9dab70465757d87",
+				Parent:    "6cb80b2c1a4b44bfdc5568ce52b0dd7781aeca97",
+				Author:    "Jane Doe <jane@example.com> 1700000000 +0100",
+				Committer: "John Roe <john@example.com> 1700000100 +0000",
+				Message: "Add b\n\nThe b file   \n\n\n# Please enter the commit message\nholds the second letter.\n\n" +
+					"Bug: 123\nSigned-off-by: Jane Doe <jane@example.com>\n# diff below\ndiff --git a/b.txt b/b.txt\n+b\n",
+			},
+			want: "Ib0632e6ec0826a0f357cff7df5f12022967dcbda",
+		},
+		{
+			name: "root commit",
+			input: ChangeIDInput{
+				Tree:      "08585692ce06452da6f82ae66b90d98b55536fca",
+				Author:    "Jane Doe <jane@example.com> 1700000000 +0100",
+				Committer: "John Roe <john@example.com> 1700000100 +0000",
+				Message:   "Root commit\n",
+			},
+			want: "I35ece140b97104dfcd84fd6bf55e38e312658e59",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GenerateChangeID(tt.input); got != tt.want {
+				t.Errorf("GenerateChangeID() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
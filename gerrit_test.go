@@ -0,0 +1,41 @@
+package gerrit
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestGerrit returns a client for a test server answering the API requests with handler.
+func newTestGerrit(t *testing.T, handler http.HandlerFunc) *Gerrit {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, server.Client())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+// writeJSON answers a request like Gerrit does, with v as JSON after the magic prefix line.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(magicPrefix)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// readJSON decodes the JSON body of a request into v.
+func readJSON(t *testing.T, r *http.Request, v interface{}) {
+	t.Helper()
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatalf("read request body: %v", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("decode request body %q: %v", data, err)
+	}
+}
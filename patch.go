@@ -0,0 +1,230 @@
+package gerrit
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// filePatch is the part of a unified diff that modifies a single file.
+type filePatch struct {
+	OldPath  string
+	NewPath  string
+	IsNew    bool
+	IsDelete bool
+	IsRename bool
+	IsBinary bool
+	Hunks    []hunk
+}
+
+// hunk is a contiguous block of changes of a filePatch.
+// Lines keep their leading ' ', '-', '+' or '\' marker.
+type hunk struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+	Lines              []string
+}
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// parseUnifiedDiff splits a unified diff, as produced by "git diff" or "diff -u", into per-file patches.
+// Hunk lines keep their carriage returns, so that patches of files with CRLF line endings apply as they are.
+func parseUnifiedDiff(patch string) ([]*filePatch, error) {
+	var (
+		files   []*filePatch
+		current *filePatch
+	)
+
+	lines := strings.Split(patch, "\n")
+	for i := 0; i < len(lines); i++ {
+		// Header lines are compared without their line ending, hunk lines below are taken as they are.
+		line := strings.TrimSuffix(lines[i], "\r")
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			current = &filePatch{}
+			files = append(files, current)
+			if a, b, ok := splitGitDiffHeader(strings.TrimPrefix(line, "diff --git ")); ok {
+				current.OldPath, current.NewPath = a, b
+			}
+
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			if current == nil || len(current.Hunks) > 0 {
+				current = &filePatch{}
+				files = append(files, current)
+			}
+			if p := diffHeaderPath(line[4:], "a/"); p != "" {
+				current.OldPath = p
+			} else {
+				current.IsNew = true
+			}
+			if p := diffHeaderPath(lines[i+1][4:], "b/"); p != "" {
+				current.NewPath = p
+			} else {
+				current.IsDelete = true
+			}
+			i++
+
+		case current == nil:
+			// Text before the first file, e.g. the commit message of a formatted patch.
+
+		case strings.HasPrefix(line, "new file mode"):
+			current.IsNew = true
+		case strings.HasPrefix(line, "deleted file mode"):
+			current.IsDelete = true
+		case strings.HasPrefix(line, "rename from "):
+			current.IsRename = true
+			current.OldPath = strings.TrimPrefix(line, "rename from ")
+		case strings.HasPrefix(line, "rename to "):
+			current.IsRename = true
+			current.NewPath = strings.TrimPrefix(line, "rename to ")
+		case strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch":
+			current.IsBinary = true
+
+		case strings.HasPrefix(line, "@@ "):
+			m := hunkHeader.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("malformed hunk header %q", line)
+			}
+			h := hunk{
+				OldStart: atoiDefault(m[1], 0),
+				OldLines: atoiDefault(m[2], 1),
+				NewStart: atoiDefault(m[3], 0),
+				NewLines: atoiDefault(m[4], 1),
+			}
+
+			oldSeen, newSeen := 0, 0
+			for i+1 < len(lines) && (oldSeen < h.OldLines || newSeen < h.NewLines || strings.HasPrefix(lines[i+1], `\`)) {
+				i++
+				l := lines[i]
+				if l == "" {
+					// Some tools strip the trailing space of empty context lines.
+					l = " "
+				}
+				switch l[0] {
+				case ' ':
+					oldSeen++
+					newSeen++
+				case '-':
+					oldSeen++
+				case '+':
+					newSeen++
+				case '\\':
+				default:
+					return nil, fmt.Errorf("%s: unexpected line %q in hunk", current.NewPath, l)
+				}
+				h.Lines = append(h.Lines, l)
+			}
+			if oldSeen != h.OldLines || newSeen != h.NewLines {
+				return nil, fmt.Errorf("%s: truncated hunk %q", current.NewPath, line)
+			}
+			current.Hunks = append(current.Hunks, h)
+		}
+	}
+
+	for _, f := range files {
+		if f.IsDelete && f.NewPath == "" {
+			f.NewPath = f.OldPath
+		}
+		if f.IsNew && f.OldPath == "" {
+			f.OldPath = f.NewPath
+		}
+		if f.NewPath == "" {
+			return nil, fmt.Errorf("cannot determine the file path of a patch")
+		}
+	}
+
+	return files, nil
+}
+
+// apply applies the hunks of the patch to the content of the file before the change.
+func (f *filePatch) apply(base []byte) ([]byte, error) {
+	if f.IsBinary {
+		return nil, fmt.Errorf("%s: binary patches are not supported", f.NewPath)
+	}
+
+	var old []string
+	if len(base) > 0 {
+		old = strings.SplitAfter(string(base), "\n")
+		if old[len(old)-1] == "" {
+			old = old[:len(old)-1]
+		}
+	}
+
+	var out []string
+	pos := 0
+	for _, h := range f.Hunks {
+		start := h.OldStart - 1
+		if h.OldLines == 0 {
+			// A pure insertion refers to the line after which the new lines go.
+			start = h.OldStart
+		}
+		if start < pos || start > len(old) {
+			return nil, fmt.Errorf("%s: hunk at line %d does not apply", f.NewPath, h.OldStart)
+		}
+		out = append(out, old[pos:start]...)
+		pos = start
+
+		var prev byte
+		for _, l := range h.Lines {
+			marker, text := l[0], l[1:]
+			switch marker {
+			case ' ', '-':
+				if pos >= len(old) || strings.TrimSuffix(old[pos], "\n") != text {
+					return nil, fmt.Errorf("%s: hunk at line %d does not apply", f.NewPath, h.OldStart)
+				}
+				if marker == ' ' {
+					out = append(out, old[pos])
+				}
+				pos++
+			case '+':
+				out = append(out, text+"\n")
+			case '\\':
+				// "\ No newline at end of file" refers to the preceding line of the new file.
+				if (prev == '+' || prev == ' ') && len(out) > 0 {
+					out[len(out)-1] = strings.TrimSuffix(out[len(out)-1], "\n")
+				}
+			}
+			prev = marker
+		}
+	}
+	out = append(out, old[pos:]...)
+
+	return []byte(strings.Join(out, "")), nil
+}
+
+// splitGitDiffHeader splits the "a/old b/new" part of a "diff --git" line.
+func splitGitDiffHeader(s string) (string, string, bool) {
+	if !strings.HasPrefix(s, "a/") {
+		return "", "", false
+	}
+	i := strings.Index(s, " b/")
+	if i < 0 {
+		return "", "", false
+	}
+	return s[2:i], s[i+3:], true
+}
+
+// diffHeaderPath returns the path of a "---" or "+++" line, or "" for /dev/null.
+func diffHeaderPath(s, prefix string) string {
+	if i := strings.IndexByte(s, '\t'); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimSpace(s)
+	if s == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(s, prefix)
+}
+
+func atoiDefault(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return def
+	}
+	return n
+}
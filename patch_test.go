@@ -0,0 +1,237 @@
+package gerrit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilePatchApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    string
+		base     string
+		path     string
+		isNew    bool
+		isDelete bool
+		want     string
+		wantErr  string
+	}{
+		{
+			name: "multiple hunks",
+			patch: `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ one
+-two
++TWO
+ three
+@@ -7,3 +7,4 @@
+ seven
+ eight
++eight and a half
+ nine
+`,
+			base: "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n",
+			path: "main.go",
+			want: "one\nTWO\nthree\nfour\nfive\nsix\nseven\neight\neight and a half\nnine\nten\n",
+		},
+		{
+			name: "pure insertion",
+			patch: `--- a/list.txt
++++ b/list.txt
+@@ -2,0 +3,2 @@
++c
++d
+`,
+			base: "a\nb\ne\n",
+			path: "list.txt",
+			want: "a\nb\nc\nd\ne\n",
+		},
+		{
+			name: "no newline at end of new file",
+			patch: `--- a/README
++++ b/README
+@@ -1,2 +1,2 @@
+ title
+-body
++body
+\ No newline at end of file
+`,
+			base: "title\nbody\n",
+			path: "README",
+			want: "title\nbody",
+		},
+		{
+			name: "no newline at end of old file",
+			patch: `--- a/README
++++ b/README
+@@ -1,2 +1,3 @@
+ title
+-body
+\ No newline at end of file
++body
++footer
+`,
+			base: "title\nbody",
+			path: "README",
+			want: "title\nbody\nfooter\n",
+		},
+		{
+			name: "new file",
+			patch: `diff --git a/docs/new.md b/docs/new.md
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1,2 @@
++# New
++text
+`,
+			path:  "docs/new.md",
+			isNew: true,
+			want:  "# New\ntext\n",
+		},
+		{
+			name: "deleted file",
+			patch: `diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index 4444444..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1,2 +0,0 @@
+-gone
+-too
+`,
+			base:     "gone\ntoo\n",
+			path:     "old.txt",
+			isDelete: true,
+			want:     "",
+		},
+		{
+			name: "CRLF line endings",
+			patch: strings.ReplaceAll(`diff --git a/win.txt b/win.txt
+--- a/win.txt
++++ b/win.txt
+@@ -1,3 +1,4 @@
+ one
+-two
++TWO
++two and a half
+ three
+`, "\n", "\r\n"),
+			base: "one\r\ntwo\r\nthree\r\n",
+			path: "win.txt",
+			want: "one\r\nTWO\r\ntwo and a half\r\nthree\r\n",
+		},
+		{
+			name:  "CRLF lines in an LF patch",
+			patch: "--- a/mixed.txt\n+++ b/mixed.txt\n@@ -1,2 +1,2 @@\n one\r\n-two\r\n+TWO\r\n",
+			base:  "one\r\ntwo\r\n",
+			path:  "mixed.txt",
+			want:  "one\r\nTWO\r\n",
+		},
+		{
+			name: "context mismatch",
+			patch: `--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ one
+-two
++TWO
+ three
+`,
+			base:    "one\ndeux\nthree\n",
+			path:    "main.go",
+			wantErr: "main.go: hunk at line 1 does not apply",
+		},
+		{
+			name: "hunk beyond end of file",
+			patch: `--- a/main.go
++++ b/main.go
+@@ -10,1 +10,1 @@
+-ten
++TEN
+`,
+			base:    "one\n",
+			path:    "main.go",
+			wantErr: "main.go: hunk at line 10 does not apply",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := parseUnifiedDiff(tt.patch)
+			if err != nil {
+				t.Fatalf("parseUnifiedDiff() error = %v", err)
+			}
+			if len(files) != 1 {
+				t.Fatalf("parseUnifiedDiff() returned %d files, want 1", len(files))
+			}
+			f := files[0]
+			if f.NewPath != tt.path || f.IsNew != tt.isNew || f.IsDelete != tt.isDelete {
+				t.Errorf("parseUnifiedDiff() = path %q, new %v, delete %v, want path %q, new %v, delete %v",
+					f.NewPath, f.IsNew, f.IsDelete, tt.path, tt.isNew, tt.isDelete)
+			}
+
+			got, err := f.apply([]byte(tt.base))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("apply() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("apply() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("apply() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUnifiedDiff(t *testing.T) {
+	patch := `From 5555555 Mon Sep 17 00:00:00 2001
+Subject: [PATCH] Touch two files
+
+---
+diff --git a/a.txt b/a.txt
+--- a/a.txt
++++ b/a.txt
+@@ -1 +1 @@
+-a
++A
+diff --git a/old name.txt b/new name.txt
+similarity index 100%
+rename from old name.txt
+rename to new name.txt
+diff --git a/logo.png b/logo.png
+Binary files a/logo.png and b/logo.png differ
+`
+	files, err := parseUnifiedDiff(patch)
+	if err != nil {
+		t.Fatalf("parseUnifiedDiff() error = %v", err)
+	}
+
+	var got []string
+	for _, f := range files {
+		got = append(got, strings.Join([]string{f.OldPath, f.NewPath}, " -> "))
+	}
+	want := []string{"a.txt -> a.txt", "old name.txt -> new name.txt", "logo.png -> logo.png"}
+	if strings.Join(got, "; ") != strings.Join(want, "; ") {
+		t.Fatalf("parseUnifiedDiff() files = %q, want %q", got, want)
+	}
+	if len(files[0].Hunks) != 1 || !files[1].IsRename || !files[2].IsBinary {
+		t.Errorf("parseUnifiedDiff() = hunks %d, rename %v, binary %v, want 1, true, true",
+			len(files[0].Hunks), files[1].IsRename, files[2].IsBinary)
+	}
+	if _, err := files[2].apply(nil); err == nil {
+		t.Error("apply() of a binary patch succeeded, want an error")
+	}
+
+	if _, err := parseUnifiedDiff("--- a/x\n+++ b/x\n@@ -1,2 +1,2 @@\n-x\n"); err == nil {
+		t.Error("parseUnifiedDiff() of a truncated hunk succeeded, want an error")
+	}
+}
@@ -5,15 +5,19 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 )
 
 type Project struct {
-	Raw      *ProjectInfo
-	gerrit   *Gerrit
-	Base     string
-	Branches *BranchService
-	Tags     *TagService
-	Commits  *CommitService
+	Raw                *ProjectInfo
+	gerrit             *Gerrit
+	Base               string
+	Branches           *BranchService
+	Tags               *TagService
+	Commits            *CommitService
+	Labels             *LabelService
+	SubmitRequirements *SubmitRequirementService
+	Dashboards         *DashboardService
 }
 
 // ProjectInfo entity contains information about a project.
@@ -94,6 +98,14 @@ type MaxObjectSizeLimitInfo struct {
 	InheritedValue  string `json:"inherited_value,omitempty"`
 }
 
+// SubmitTypeInfo entity contains information about the default submit type of a project,
+// taking into account project inheritance.
+type SubmitTypeInfo struct {
+	Value           string `json:"value,omitempty"`
+	ConfiguredValue string `json:"configured_value,omitempty"`
+	InheritedValue  string `json:"inherited_value,omitempty"`
+}
+
 // ConfigParameterInfo entity describes a project configuration parameter.
 type ConfigParameterInfo struct {
 	DisplayName string   `json:"display_name,omitempty"`
@@ -121,7 +133,10 @@ type ConfigInfo struct {
 	CreateNewChangeForAllNotInTarget InheritedBooleanInfo           `json:"create_new_change_for_all_not_in_target,omitempty"`
 	RequireChangeID                  InheritedBooleanInfo           `json:"require_change_id,omitempty"`
 	EnableSignedPush                 InheritedBooleanInfo           `json:"enable_signed_push,omitempty"`
+	RequireSignedPush                InheritedBooleanInfo           `json:"require_signed_push,omitempty"`
+	RejectImplicitMerges             InheritedBooleanInfo           `json:"reject_implicit_merges,omitempty"`
 	MaxObjectSizeLimit               MaxObjectSizeLimitInfo         `json:"max_object_size_limit"`
+	DefaultSubmitType                SubmitTypeInfo                 `json:"default_submit_type,omitempty"`
 	SubmitType                       string                         `json:"submit_type"`
 	State                            string                         `json:"state,omitempty"`
 	Commentlinks                     map[string]string              `json:"commentlinks"`
@@ -196,6 +211,49 @@ type ProjectService struct {
 	gerrit *Gerrit
 }
 
+// ProjectOperationResult reports the outcome of a batch operation for one project.
+type ProjectOperationResult struct {
+	Project string
+
+	// Skipped is true when the operation was not attempted, e.g. because it is a dry run
+	// or the project is already in the requested state. Reason tells why.
+	Skipped bool
+	Reason  string
+
+	Err error
+}
+
+// forEachProject calls fn for the projects 0 to n-1 of a batch operation, with at most concurrency calls at a time.
+// Projects that are not started because the context is done are marked as skipped in their result.
+func forEachProject(ctx context.Context, n, concurrency int, result func(i int) *ProjectOperationResult, fn func(i int)) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			result(i).Skipped, result(i).Reason = true, err.Error()
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			result(i).Skipped, result(i).Reason = true, ctx.Err().Error()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
 func NewProject(gerrit *Gerrit, projectName string) *Project {
 	obj := &Project{
 		Raw:    new(ProjectInfo),
@@ -206,6 +264,9 @@ func NewProject(gerrit *Gerrit, projectName string) *Project {
 	obj.Branches = &BranchService{gerrit: gerrit, project: obj}
 	obj.Tags = &TagService{gerrit: gerrit, project: obj}
 	obj.Commits = &CommitService{gerrit: gerrit, project: obj}
+	obj.Labels = &LabelService{gerrit: gerrit, project: obj}
+	obj.SubmitRequirements = &SubmitRequirementService{gerrit: gerrit, project: obj}
+	obj.Dashboards = &DashboardService{gerrit: gerrit, project: obj}
 
 	return obj
 }
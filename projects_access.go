@@ -0,0 +1,109 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ProjectAccessInput describes changes that should be applied to a project access config.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#project-access-input
+type ProjectAccessInput struct {
+	// Remove are the access sections, permissions or rules to remove, keyed by ref pattern.
+	// A section without permissions removes the whole section, a permission without rules the whole permission.
+	Remove map[string]AccessSectionInfo `json:"remove,omitempty"`
+
+	// Add are the access sections to add or update, keyed by ref pattern.
+	Add map[string]AccessSectionInfo `json:"add,omitempty"`
+
+	// Message is the commit message of the change to refs/meta/config.
+	Message string `json:"message,omitempty"`
+
+	// Parent is the name of the new parent project.
+	Parent string `json:"parent,omitempty"`
+}
+
+// AccessCheckInfo entity is the result of an access check.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#access-check-info
+type AccessCheckInfo struct {
+	Message string `json:"message,omitempty"`
+
+	// Status is the HTTP status code the access would result in, 200 when access is granted.
+	Status int `json:"status"`
+
+	DebugLogs []string `json:"debug_logs,omitempty"`
+}
+
+// CheckAccessOptions specifies the parameters to Project.CheckAccess.
+type CheckAccessOptions struct {
+	// Account is the account for which to check access. Mandatory.
+	Account string `url:"account"`
+
+	// Permission is the ref permission to check, e.g. "push" or "label-Code-Review". Requires Ref.
+	Permission string `url:"perm,omitempty"`
+
+	// Ref is the ref for which to check access. Read access to the project is checked when empty.
+	Ref string `url:"ref,omitempty"`
+}
+
+// GetAccess lists the access rights for a single project.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-access
+func (p *Project) GetAccess(ctx context.Context) (*ProjectAccessInfo, *http.Response, error) {
+	v := new(ProjectAccessInfo)
+	u := fmt.Sprintf("projects/%s/access", url.QueryEscape(p.Base))
+
+	resp, err := p.gerrit.Requester.Call(ctx, "GET", u, nil, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// SetAccess adds and removes permissions of a project and sets its parent, directly in refs/meta/config.
+// As response the resulting access rights of the project are returned.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#set-access
+func (p *Project) SetAccess(ctx context.Context, input *ProjectAccessInput) (*ProjectAccessInfo, *http.Response, error) {
+	v := new(ProjectAccessInfo)
+	u := fmt.Sprintf("projects/%s/access", url.QueryEscape(p.Base))
+
+	resp, err := p.gerrit.Requester.Call(ctx, "POST", u, input, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// CreateAccessChange creates a change for review that applies the access rights modifications to refs/meta/config,
+// instead of applying them directly like SetAccess.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#create-access-change
+func (p *Project) CreateAccessChange(ctx context.Context, input *ProjectAccessInput) (*ChangeInfo, *http.Response, error) {
+	v := new(ChangeInfo)
+	u := fmt.Sprintf("projects/%s/access:review", url.QueryEscape(p.Base))
+
+	resp, err := p.gerrit.Requester.Call(ctx, "PUT", u, input, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// CheckAccess runs an access check for an account on the project, and optionally on a ref and permission.
+// A denied access is not an error: it is reported in the Status of the result.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#check-access
+func (p *Project) CheckAccess(ctx context.Context, opt *CheckAccessOptions) (*AccessCheckInfo, *http.Response, error) {
+	v := new(AccessCheckInfo)
+	u := fmt.Sprintf("projects/%s/check.access", url.QueryEscape(p.Base))
+
+	resp, err := p.gerrit.Requester.Call(ctx, "GET", u, opt, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
@@ -0,0 +1,96 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestProjectAccess(t *testing.T) {
+	input := &ProjectAccessInput{
+		Add: map[string]AccessSectionInfo{"refs/heads/*": {Permissions: map[string]PermissionInfo{
+			"label-Code-Review": {Label: "Code-Review", Rules: map[string]PermissionRuleInfo{
+				"group-uuid": {Action: PermissionActionAllow, Min: -2, Max: 2},
+			}},
+		}}},
+		Remove:  map[string]AccessSectionInfo{"refs/tags/*": {}},
+		Message: "Update access",
+		Parent:  "All-Projects",
+	}
+	access := ProjectAccessInfo{Revision: "a1b2c3", Local: input.Add}
+
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "GET /projects/parent%2Fchild/access":
+			writeJSON(w, access)
+		case "POST /projects/parent%2Fchild/access":
+			var in ProjectAccessInput
+			readJSON(t, r, &in)
+			if !reflect.DeepEqual(&in, input) {
+				t.Errorf("SetAccess() input = %+v, want %+v", in, input)
+			}
+			writeJSON(w, access)
+		case "PUT /projects/parent%2Fchild/access:review":
+			var in ProjectAccessInput
+			readJSON(t, r, &in)
+			if !reflect.DeepEqual(&in, input) {
+				t.Errorf("CreateAccessChange() input = %+v, want %+v", in, input)
+			}
+			w.WriteHeader(http.StatusCreated)
+			writeJSON(w, ChangeInfo{Number: 42, Project: "parent/child", Branch: "refs/meta/config"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	})
+	project := NewProject(client, "parent/child")
+	ctx := context.Background()
+
+	got, _, err := project.GetAccess(ctx)
+	if err != nil || !reflect.DeepEqual(*got, access) {
+		t.Errorf("GetAccess() = %+v, %v, want %+v", got, err, access)
+	}
+	got, _, err = project.SetAccess(ctx, input)
+	if err != nil || got.Revision != "a1b2c3" {
+		t.Errorf("SetAccess() = %+v, %v, want the resulting access", got, err)
+	}
+	change, _, err := project.CreateAccessChange(ctx, input)
+	if err != nil || change.Number != 42 {
+		t.Errorf("CreateAccessChange() = %+v, %v, want change 42", change, err)
+	}
+}
+
+func TestProjectCheckAccess(t *testing.T) {
+	tests := []struct {
+		name      string
+		opt       CheckAccessOptions
+		wantQuery string
+		status    int
+	}{
+		{"read access", CheckAccessOptions{Account: "jane"}, "account=jane", http.StatusOK},
+		{
+			"denied push",
+			CheckAccessOptions{Account: "jane", Permission: "push", Ref: "refs/heads/main"},
+			"account=jane&perm=push&ref=refs%2Fheads%2Fmain",
+			http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/projects/myProject/check.access" || r.URL.RawQuery != tt.wantQuery {
+					t.Errorf("request = %s, want the query %s", r.URL, tt.wantQuery)
+				}
+				writeJSON(w, AccessCheckInfo{Status: tt.status, Message: "checked"})
+			})
+
+			check, _, err := NewProject(client, "myProject").CheckAccess(context.Background(), &tt.opt)
+			if err != nil {
+				t.Fatalf("CheckAccess() error = %v", err)
+			}
+			if check.Status != tt.status {
+				t.Errorf("CheckAccess() Status = %d, want %d", check.Status, tt.status)
+			}
+		})
+	}
+}
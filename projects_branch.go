@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 )
 
 type Branch struct {
@@ -24,8 +26,28 @@ type BranchInfo struct {
 
 // BranchInput entity contains information for the creation of a new branch.
 type BranchInput struct {
-	Ref      string `json:"ref,omitempty"`
+	Ref string `json:"ref,omitempty"`
+
+	// Revision is the base revision of the new branch: a SHA-1, a ref or a branch name. HEAD is used when empty.
 	Revision string `json:"revision,omitempty"`
+
+	// CreateEmptyCommit creates the branch with an empty initial commit instead of using Revision.
+	CreateEmptyCommit bool `json:"create_empty_commit,omitempty"`
+
+	// ValidationOptions are passed as push options to the ref-operation validation listeners.
+	ValidationOptions map[string]string `json:"validation_options,omitempty"`
+}
+
+// ReflogOptions specifies the parameters to Branch.GetReflogWithOptions.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-reflog
+type ReflogOptions struct {
+	// Limit the number of reflog entries to be included in the results.
+	Limit int `url:"n,omitempty"`
+
+	// From and To limit the results to the entries in a time range. They have a precision of minutes.
+	From time.Time `url:"from,omitempty" layout:"20060102_1504"`
+	To   time.Time `url:"to,omitempty" layout:"20060102_1504"`
 }
 
 // DeleteBranchesInput entity contains information about branches that should be deleted.
@@ -118,6 +140,60 @@ func (s *BranchService) Create(ctx context.Context, branchID string, input *Bran
 	return obj.Create(ctx, input)
 }
 
+// CreateFromBranch creates a new branch pointing to the current revision of another branch, e.g. "main".
+// The Revision of the optional input is ignored.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#create-branch
+func (s *BranchService) CreateFromBranch(ctx context.Context, branchID, sourceBranch string, input *BranchInput) (*Branch, *http.Response, error) {
+	in := BranchInput{}
+	if input != nil {
+		in = *input
+	}
+	in.Revision = fullBranchRef(sourceBranch)
+	return s.Create(ctx, branchID, &in)
+}
+
+// CreateFromChange creates a new branch pointing to a patch set of a change, any reference ParseChangeRef understands.
+// The current patch set is used unless the reference names one. The Revision of the optional input is ignored.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#create-branch
+func (s *BranchService) CreateFromChange(ctx context.Context, branchID, changeID string, input *BranchInput) (*Branch, *http.Response, error) {
+	ref, err := ParseChangeRef(changeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := "CURRENT_REVISION"
+	if ref.PatchSet != 0 {
+		fields = "ALL_REVISIONS"
+	}
+	change, resp, err := s.gerrit.Changes.GetRef(ctx, ref, fields)
+	if err != nil {
+		return nil, resp, err
+	}
+	if change.Raw.Project != s.project.Base {
+		return nil, resp, fmt.Errorf("change %s belongs to project %s, not %s", changeID, change.Raw.Project, s.project.Base)
+	}
+
+	in := BranchInput{}
+	if input != nil {
+		in = *input
+	}
+	in.Revision = change.Raw.CurrentRevision
+	if ref.PatchSet != 0 {
+		in.Revision = ""
+		for sha, revision := range change.Raw.Revisions {
+			if revision.Number == ref.PatchSet {
+				in.Revision = sha
+			}
+		}
+		if in.Revision == "" {
+			return nil, resp, fmt.Errorf("change %s has no patch set %d", changeID, ref.PatchSet)
+		}
+	}
+	return s.Create(ctx, branchID, &in)
+}
+
 // Delete deletes a branch.
 //
 // Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#delete-branch
@@ -146,15 +222,13 @@ func (b *Branch) Poll(ctx context.Context) (*http.Response, error) {
 	return b.gerrit.Requester.Call(ctx, "GET", u, nil, b.Raw)
 }
 
+// Create creates the branch. The BranchInfo returned by Gerrit, including the web links of the new branch, is stored in b.Raw.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#create-branch
 func (b *Branch) Create(ctx context.Context, input *BranchInput) (*Branch, *http.Response, error) {
 	u := fmt.Sprintf("projects/%s/branches/%s", url.QueryEscape(b.project.Base), url.QueryEscape(b.Base))
-	resp, err := b.gerrit.Requester.Call(ctx, "PUT", u, input, nil)
-
-	if err != nil {
-		return nil, resp, err
-	}
+	resp, err := b.gerrit.Requester.Call(ctx, "PUT", u, input, b.Raw)
 
-	resp, err = b.Poll(ctx)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -215,14 +289,30 @@ func (b *Branch) GetMergeableInformation(ctx context.Context, opt *MergeOptions)
 //
 // Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-reflog
 func (b *Branch) GetReflog(ctx context.Context) (*[]ReflogEntryInfo, *http.Response, error) {
+	return b.GetReflogWithOptions(ctx, nil)
+}
+
+// GetReflogWithOptions gets the reflog of a certain branch, newest entry first. The entries can be limited and filtered by time.
+// The caller must be project owner.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-reflog
+func (b *Branch) GetReflogWithOptions(ctx context.Context, opt *ReflogOptions) (*[]ReflogEntryInfo, *http.Response, error) {
 	v := new([]ReflogEntryInfo)
 	u := fmt.Sprintf("projects/%s/branches/%s/reflog",
 		url.QueryEscape(b.project.Base),
 		url.QueryEscape(b.Base))
 
-	resp, err := b.gerrit.Requester.Call(ctx, "GET", u, nil, v)
+	resp, err := b.gerrit.Requester.Call(ctx, "GET", u, opt, v)
 	if err != nil {
 		return nil, resp, err
 	}
 	return v, resp, nil
-}
\ No newline at end of file
+}
+
+// fullBranchRef returns the full ref name of a branch, e.g. "refs/heads/main" for "main".
+func fullBranchRef(branch string) string {
+	if strings.HasPrefix(branch, "refs/") {
+		return branch
+	}
+	return "refs/heads/" + branch
+}
@@ -0,0 +1,226 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+)
+
+// BulkBranchOptions specifies the projects a cross-project branch operation runs on, and how.
+type BulkBranchOptions struct {
+	// Projects are the names of the projects to operate on.
+	Projects []string
+
+	// Query selects more projects with ProjectService.List, e.g. all projects with a prefix.
+	Query *ProjectOptions
+
+	// Concurrency is the maximum number of projects operated on at a time. Defaults to 1.
+	Concurrency int
+
+	// DryRun only reports what would be done, without changing any branch.
+	DryRun bool
+}
+
+// BranchOperationResult reports the outcome of a cross-project branch operation for one project.
+type BranchOperationResult struct {
+	ProjectOperationResult
+
+	// Branches are the branches that were created or deleted, or would be in a dry run.
+	Branches []string
+
+	// Branch is the created branch.
+	Branch *BranchInfo
+
+	// Mergeable is the result of ProjectService.CheckBranchesMergeable.
+	Mergeable *MergeableInfo
+}
+
+// CreateBranches creates a branch on every project selected by opt, with BranchService.Create.
+// The branch is created from input.Revision, or from the HEAD of each project when it is empty.
+// Projects that already have the branch are skipped; a failure for one project does not prevent the others.
+// Results are sorted by project name.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#create-branch
+func (s *ProjectService) CreateBranches(ctx context.Context, branchID string, input *BranchInput, opt *BulkBranchOptions) ([]BranchOperationResult, *http.Response, error) {
+	o, results, resp, err := s.bulkBranchProjects(ctx, opt)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	forEachProject(ctx, len(results), o.Concurrency,
+		func(i int) *ProjectOperationResult { return &results[i].ProjectOperationResult },
+		func(i int) {
+			result := &results[i]
+			project := NewProject(s.gerrit, result.Project)
+
+			exists, err := branchExists(ctx, project, branchID)
+			switch {
+			case err != nil:
+				result.Err = err
+				return
+			case exists:
+				result.Skipped, result.Reason = true, "branch already exists"
+				return
+			}
+
+			result.Branches = []string{branchID}
+			if o.DryRun {
+				result.Skipped, result.Reason = true, "dry run"
+				return
+			}
+			branch, _, err := project.Branches.Create(ctx, branchID, input)
+			if err != nil {
+				result.Branches, result.Err = nil, err
+				return
+			}
+			result.Branch = branch.Raw
+		})
+
+	return results, resp, nil
+}
+
+// DeleteBranches deletes branches on every project selected by opt, with BranchService.BulkDelete.
+// Only the branches that exist on a project are deleted. The branch its HEAD points to is never deleted,
+// which is reported in the Reason of the result while the other branches are still deleted.
+// Projects with none of the branches are skipped; a failure for one project does not prevent the others.
+// Results are sorted by project name.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#delete-branches
+func (s *ProjectService) DeleteBranches(ctx context.Context, branches []string, opt *BulkBranchOptions) ([]BranchOperationResult, *http.Response, error) {
+	o, results, resp, err := s.bulkBranchProjects(ctx, opt)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	forEachProject(ctx, len(results), o.Concurrency,
+		func(i int) *ProjectOperationResult { return &results[i].ProjectOperationResult },
+		func(i int) {
+			result := &results[i]
+			project := NewProject(s.gerrit, result.Project)
+
+			head, _, err := project.GetHEAD(ctx)
+			if err != nil {
+				result.Err = err
+				return
+			}
+
+			var existing []string
+			for _, branch := range branches {
+				if fullBranchRef(branch) == head {
+					result.Reason = "refusing to delete HEAD branch " + head
+					continue
+				}
+				exists, err := branchExists(ctx, project, branch)
+				if err != nil {
+					result.Err = err
+					return
+				}
+				if exists {
+					existing = append(existing, branch)
+				}
+			}
+
+			if len(existing) == 0 {
+				result.Skipped = true
+				if result.Reason == "" {
+					result.Reason = "no branch to delete"
+				}
+				return
+			}
+			result.Branches = existing
+			if o.DryRun {
+				result.Skipped = true
+				if result.Reason == "" {
+					result.Reason = "dry run"
+				} else {
+					result.Reason = "dry run; " + result.Reason
+				}
+				return
+			}
+			if _, _, err := project.Branches.BulkDelete(ctx, &DeleteBranchesInput{Branches: existing}); err != nil {
+				result.Branches, result.Err = nil, err
+			}
+		})
+
+	return results, resp, nil
+}
+
+// CheckBranchesMergeable checks on every project selected by opt whether source can be merged into the target branch,
+// with Branch.GetMergeableInformation. Projects without the target branch are skipped. DryRun has no effect.
+// Results are sorted by project name.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-mergeable-info
+func (s *ProjectService) CheckBranchesMergeable(ctx context.Context, target string, merge *MergeOptions, opt *BulkBranchOptions) ([]BranchOperationResult, *http.Response, error) {
+	o, results, resp, err := s.bulkBranchProjects(ctx, opt)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	forEachProject(ctx, len(results), o.Concurrency,
+		func(i int) *ProjectOperationResult { return &results[i].ProjectOperationResult },
+		func(i int) {
+			result := &results[i]
+			project := NewProject(s.gerrit, result.Project)
+
+			exists, err := branchExists(ctx, project, target)
+			switch {
+			case err != nil:
+				result.Err = err
+				return
+			case !exists:
+				result.Skipped, result.Reason = true, "no branch "+target
+				return
+			}
+
+			branch := Branch{Raw: new(BranchInfo), gerrit: s.gerrit, project: project, Base: target}
+			result.Mergeable, _, result.Err = branch.GetMergeableInformation(ctx, merge)
+		})
+
+	return results, resp, nil
+}
+
+// bulkBranchProjects resolves the projects of a cross-project branch operation and prepares a result per project.
+func (s *ProjectService) bulkBranchProjects(ctx context.Context, opt *BulkBranchOptions) (BulkBranchOptions, []BranchOperationResult, *http.Response, error) {
+	o := BulkBranchOptions{}
+	if opt != nil {
+		o = *opt
+	}
+
+	names := make(map[string]bool)
+	for _, name := range o.Projects {
+		names[name] = true
+	}
+
+	var resp *http.Response
+	if o.Query != nil {
+		projects, r, err := s.List(ctx, o.Query)
+		resp = r
+		if err != nil {
+			return o, nil, resp, err
+		}
+		for name := range projects {
+			names[name] = true
+		}
+	}
+
+	results := make([]BranchOperationResult, 0, len(names))
+	for name := range names {
+		results = append(results, BranchOperationResult{ProjectOperationResult: ProjectOperationResult{Project: name}})
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Project < results[j].Project })
+	return o, results, resp, nil
+}
+
+// branchExists reports whether a project has a branch.
+func branchExists(ctx context.Context, project *Project, branchID string) (bool, error) {
+	_, _, err := project.Branches.Get(ctx, branchID)
+	var e *ErrorResponse
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.As(err, &e) && e.Response.StatusCode == http.StatusNotFound:
+		return false, nil
+	}
+	return false, err
+}
@@ -0,0 +1,239 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// branchServer serves projects with their branches and HEAD. Projects named "broken/..." fail every request
+// but the project list, and a project missing from the map does not exist.
+type branchServer struct {
+	mu       sync.Mutex
+	branches map[string]map[string]bool
+	head     map[string]string
+	deleted  []string
+}
+
+func newBranchServer(t *testing.T) (*branchServer, *Gerrit) {
+	s := &branchServer{
+		branches: map[string]map[string]bool{
+			"platform/a": {"refs/heads/main": true, "refs/heads/old": true},
+			"platform/b": {"refs/heads/main": true, "refs/heads/stable": true, "refs/heads/old": true},
+			"platform/c": {"refs/heads/master": true},
+			"tools":      {"refs/heads/main": true, "refs/heads/stable": true},
+		},
+		head: map[string]string{"platform/a": "refs/heads/main", "platform/b": "refs/heads/main", "platform/c": "refs/heads/master", "tools": "refs/heads/stable"},
+	}
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/projects/" {
+			writeJSON(w, map[string]ProjectInfo{"platform/a": {}, "platform/b": {}, "platform/c": {}})
+			return
+		}
+		parts := strings.SplitN(strings.TrimPrefix(r.URL.EscapedPath(), "/projects/"), "/", 2)
+		project, _ := url.PathUnescape(parts[0])
+		resource, _ := url.PathUnescape(parts[1])
+		if strings.HasPrefix(project, "broken/") {
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		branches := s.branches[project]
+		switch {
+		case r.Method == "GET" && resource == "HEAD":
+			writeJSON(w, s.head[project])
+		case r.Method == "POST" && resource == "branches:delete":
+			var in DeleteBranchesInput
+			readJSON(t, r, &in)
+			for _, branch := range in.Branches {
+				delete(branches, fullBranchRef(branch))
+				s.deleted = append(s.deleted, project+" "+branch)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(resource, "/mergeable"):
+			source := r.URL.Query().Get("source")
+			writeJSON(w, MergeableInfo{SubmitType: "MERGE_IF_NECESSARY", Mergeable: source == "refs/heads/main"})
+		case r.Method == "GET" && strings.HasPrefix(resource, "branches/"):
+			ref := fullBranchRef(strings.TrimPrefix(resource, "branches/"))
+			if !branches[ref] {
+				http.Error(w, "Not found: "+ref, http.StatusNotFound)
+				return
+			}
+			writeJSON(w, BranchInfo{Ref: ref})
+		case r.Method == "PUT" && strings.HasPrefix(resource, "branches/"):
+			ref := fullBranchRef(strings.TrimPrefix(resource, "branches/"))
+			if project == "platform/c" {
+				http.Error(w, "not permitted", http.StatusForbidden)
+				return
+			}
+			branches[ref] = true
+			w.WriteHeader(http.StatusCreated)
+			writeJSON(w, BranchInfo{Ref: ref, Revision: "a1b2c3"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	})
+	return s, client
+}
+
+// branchOutcomes summarizes results as "project: outcome" lines.
+func branchOutcomes(results []BranchOperationResult) []string {
+	var outcomes []string
+	for _, result := range results {
+		var outcome string
+		switch {
+		case result.Err != nil:
+			outcome = "failed"
+		case result.Skipped:
+			outcome = "skipped (" + result.Reason + ")"
+		default:
+			outcome = "done"
+			if result.Reason != "" {
+				outcome += " (" + result.Reason + ")"
+			}
+		}
+		if len(result.Branches) > 0 {
+			outcome += " " + strings.Join(result.Branches, ",")
+		}
+		outcomes = append(outcomes, result.Project+": "+outcome)
+	}
+	return outcomes
+}
+
+func TestCreateBranches(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  BulkBranchOptions
+		want []string
+	}{
+		{
+			name: "create",
+			opt:  BulkBranchOptions{Projects: []string{"tools", "broken/x"}, Query: &ProjectOptions{Prefix: "platform/"}, Concurrency: 3},
+			want: []string{
+				"broken/x: failed",
+				"platform/a: done stable",
+				"platform/b: skipped (branch already exists)",
+				"platform/c: failed",
+				"tools: skipped (branch already exists)",
+			},
+		},
+		{
+			name: "dry run",
+			opt:  BulkBranchOptions{Projects: []string{"platform/a", "platform/b"}, DryRun: true},
+			want: []string{
+				"platform/a: skipped (dry run) stable",
+				"platform/b: skipped (branch already exists)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := newBranchServer(t)
+			results, _, err := client.Projects.CreateBranches(context.Background(), "stable", &BranchInput{Revision: "main"}, &tt.opt)
+			if err != nil {
+				t.Fatalf("CreateBranches() error = %v", err)
+			}
+			if got := branchOutcomes(results); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateBranches() = %q, want %q", got, tt.want)
+			}
+			created := server.branches["platform/a"]["refs/heads/stable"]
+			if created == tt.opt.DryRun {
+				t.Errorf("platform/a has stable = %v in a dry run %v", created, tt.opt.DryRun)
+			}
+			if !tt.opt.DryRun && results[1].Branch.Revision != "a1b2c3" {
+				t.Errorf("Branch = %+v, want the created branch", results[1].Branch)
+			}
+		})
+	}
+}
+
+func TestDeleteBranches(t *testing.T) {
+	tests := []struct {
+		name        string
+		branches    []string
+		opt         BulkBranchOptions
+		want        []string
+		wantDeleted []string
+	}{
+		{
+			name:     "delete",
+			branches: []string{"old", "refs/heads/stable"},
+			opt:      BulkBranchOptions{Projects: []string{"platform/a", "platform/b", "platform/c", "tools"}, Concurrency: 2},
+			want: []string{
+				"platform/a: done old",
+				"platform/b: done old,refs/heads/stable",
+				"platform/c: skipped (no branch to delete)",
+				// The HEAD branch is kept while the other branches are deleted.
+				"tools: skipped (refusing to delete HEAD branch refs/heads/stable)",
+			},
+			wantDeleted: []string{"platform/a old", "platform/b old", "platform/b refs/heads/stable"},
+		},
+		{
+			name:     "HEAD branch reported",
+			branches: []string{"main", "old"},
+			opt:      BulkBranchOptions{Projects: []string{"platform/a", "broken/x"}},
+			want: []string{
+				"broken/x: failed",
+				"platform/a: done (refusing to delete HEAD branch refs/heads/main) old",
+			},
+			wantDeleted: []string{"platform/a old"},
+		},
+		{
+			name:     "dry run",
+			branches: []string{"main", "old"},
+			opt:      BulkBranchOptions{Projects: []string{"platform/a", "platform/b", "platform/c"}, DryRun: true},
+			want: []string{
+				"platform/a: skipped (dry run; refusing to delete HEAD branch refs/heads/main) old",
+				"platform/b: skipped (dry run; refusing to delete HEAD branch refs/heads/main) old",
+				"platform/c: skipped (no branch to delete)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := newBranchServer(t)
+			results, _, err := client.Projects.DeleteBranches(context.Background(), tt.branches, &tt.opt)
+			if err != nil {
+				t.Fatalf("DeleteBranches() error = %v", err)
+			}
+			if got := branchOutcomes(results); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeleteBranches() = %q, want %q", got, tt.want)
+			}
+			sort.Strings(server.deleted)
+			if !reflect.DeepEqual(server.deleted, tt.wantDeleted) {
+				t.Errorf("deleted = %q, want %q", server.deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestCheckBranchesMergeable(t *testing.T) {
+	_, client := newBranchServer(t)
+	results, _, err := client.Projects.CheckBranchesMergeable(context.Background(), "stable", &MergeOptions{Source: "refs/heads/main"},
+		&BulkBranchOptions{Query: &ProjectOptions{Prefix: "platform/"}, Projects: []string{"tools"}, Concurrency: 4})
+	if err != nil {
+		t.Fatalf("CheckBranchesMergeable() error = %v", err)
+	}
+
+	want := []string{
+		"platform/a: skipped (no branch stable)",
+		"platform/b: done",
+		"platform/c: skipped (no branch stable)",
+		"tools: done",
+	}
+	if got := branchOutcomes(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckBranchesMergeable() = %q, want %q", got, want)
+	}
+	for _, result := range results {
+		if !result.Skipped && (result.Mergeable == nil || !result.Mergeable.Mergeable) {
+			t.Errorf("Mergeable of %s = %+v, want mergeable", result.Project, result.Mergeable)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package gerrit
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -32,16 +33,85 @@ func (s *CommitService) Get(ctx context.Context, commitID string) (*Commit, *htt
 	return &commit, resp, nil
 }
 
+// GetIncludedIn retrieves the branches and tags in which a commit is included.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-included-in
+func (s *CommitService) GetIncludedIn(ctx context.Context, commitID string) (*IncludedInInfo, *http.Response, error) {
+	commit := Commit{Raw: new(CommitInfo), gerrit: s.gerrit, project: s.project, Base: commitID}
+	return commit.GetIncludedIn(ctx)
+}
+
+// CherryPick cherry-picks a commit of the project to a destination branch, creating a new change.
+// Unlike Change.CherryPickRevision, the commit doesn't need to belong to a change.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#cherry-pick-commit
+func (s *CommitService) CherryPick(ctx context.Context, commitID string, input *CherryPickInput) (*ChangeInfo, *http.Response, error) {
+	commit := Commit{Raw: new(CommitInfo), gerrit: s.gerrit, project: s.project, Base: commitID}
+	return commit.CherryPick(ctx, input)
+}
+
+// ListBetween lists the commits reachable from to, following first parents only, down to but excluding from.
+// The commits are returned oldest first, e.g. to cherry-pick them in order. At most limit commits are listed,
+// 100 when limit is 0; an error is returned when from is not reached within the limit.
+//
+// The REST API has no log endpoint, so every commit is retrieved with its own request, one after the other:
+// listing n commits takes n+2 requests, and limit also bounds the number of requests. For long ranges,
+// Gitiles.GetRefLogs lists up to 100 commits per request.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-commit
+func (s *CommitService) ListBetween(ctx context.Context, from, to string, limit int) ([]CommitInfo, *http.Response, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	base, resp, err := s.Get(ctx, from)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var commits []CommitInfo
+	for id := to; ; {
+		commit, r, err := s.Get(ctx, id)
+		resp = r
+		if err != nil {
+			return nil, resp, err
+		}
+		if commit.Raw.Commit == base.Raw.Commit {
+			break
+		}
+		if len(commits) == limit {
+			return nil, resp, fmt.Errorf("%s is not within %d first-parent commits of %s", from, limit, to)
+		}
+		if len(commit.Raw.Parents) == 0 {
+			return nil, resp, fmt.Errorf("%s is not a first-parent ancestor of %s", from, to)
+		}
+		commits = append(commits, *commit.Raw)
+		id = commit.Raw.Parents[0].Commit
+	}
+
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits, resp, nil
+}
+
 func (c *Commit) Poll(ctx context.Context) (*http.Response, error) {
 	u := fmt.Sprintf("projects/%s/commits/%s", url.QueryEscape(c.project.Base), c.Base)
 	return c.gerrit.Requester.Call(ctx, "GET", u, nil, c.Raw)
 }
 
-// GetIncludeIn Retrieves the branches and tags in which a change is included.
+// GetIncludeIn retrieves the branches and tags in which a commit is included.
+//
+// Deprecated: Use GetIncludedIn, named like Change.GetIncludedIn and CommitService.GetIncludedIn.
+func (c *Commit) GetIncludeIn(ctx context.Context) (*IncludedInInfo, *http.Response, error) {
+	return c.GetIncludedIn(ctx)
+}
+
+// GetIncludedIn retrieves the branches and tags in which a commit is included.
 // Branches that are not visible to the calling user according to the project’s read permissions are filtered out from the result.
 //
 // Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-included-in
-func (c *Commit) GetIncludeIn(ctx context.Context) (*IncludedInInfo, *http.Response, error) {
+func (c *Commit) GetIncludedIn(ctx context.Context) (*IncludedInInfo, *http.Response, error) {
 	v := new(IncludedInInfo)
 	u := fmt.Sprintf("projects/%s/commits/%s/in", url.QueryEscape(c.project.Base), c.Base)
 	resp, err := c.gerrit.Requester.Call(ctx, "GET", u, nil, v)
@@ -52,11 +122,25 @@ func (c *Commit) GetIncludeIn(ctx context.Context) (*IncludedInInfo, *http.Respo
 	return v, resp, nil
 }
 
+// CherryPick cherry-picks the commit to a destination branch, creating a new change.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#cherry-pick-commit
+func (c *Commit) CherryPick(ctx context.Context, input *CherryPickInput) (*ChangeInfo, *http.Response, error) {
+	v := new(ChangeInfo)
+	u := fmt.Sprintf("projects/%s/commits/%s/cherrypick", url.QueryEscape(c.project.Base), c.Base)
+
+	resp, err := c.gerrit.Requester.Call(ctx, "POST", u, input, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
 // GetContent gets the content of a file from a certain commit.
-// The content is returned as base64 encoded string.
+// Gerrit sends the content base64 encoded; it is returned decoded.
 //
 // Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-content-from-commit
-func (c *Commit) GetContent(ctx context.Context, fileID string) (string, *http.Response, error) {
+func (c *Commit) GetContent(ctx context.Context, fileID string) ([]byte, *http.Response, error) {
 	v := new(string)
 	u := fmt.Sprintf("projects/%s/commits/%s/files/%s/content",
 		url.QueryEscape(c.project.Base),
@@ -64,11 +148,15 @@ func (c *Commit) GetContent(ctx context.Context, fileID string) (string, *http.R
 		url.QueryEscape(fileID))
 
 	resp, err := c.gerrit.Requester.Call(ctx, "GET", u, nil, v)
+	if err != nil {
+		return nil, resp, err
+	}
 
+	content, err := base64.StdEncoding.DecodeString(*v)
 	if err != nil {
-		return "", resp, err
+		return nil, resp, fmt.Errorf("decode content of %s: %w", fileID, err)
 	}
-	return *v, resp, nil
+	return content, resp, nil
 }
 
 // ListFiles gets the files that were modified, added or deleted in a commit.
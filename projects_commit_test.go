@@ -0,0 +1,136 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// commitServer serves the first-parent history c5 -> c4 -> c3 -> c2 -> c1 of myProject, where c4 is a merge of c3 and side.
+// Commit IDs may be abbreviated to their first two characters.
+func commitServer(t *testing.T, requests *int) *Gerrit {
+	history := map[string]CommitInfo{}
+	for i, id := range []string{"c1", "c2", "c3", "c4", "c5"} {
+		commit := CommitInfo{Commit: id + "000000", Subject: "Commit " + id}
+		if i > 0 {
+			commit.Parents = []CommitInfo{{Commit: "c" + string(rune('0'+i)) + "000000"}}
+		}
+		history[id] = commit
+	}
+	c4 := history["c4"]
+	c4.Parents = append(c4.Parents, CommitInfo{Commit: "side000000"})
+	history["c4"] = c4
+
+	return newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		id, ok := strings.CutPrefix(r.URL.Path, "/projects/myProject/commits/")
+		if !ok || r.Method != "GET" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+		commit, ok := history[id[:min(len(id), 2)]]
+		if !ok {
+			http.Error(w, "Not found: "+id, http.StatusNotFound)
+			return
+		}
+		writeJSON(w, commit)
+	})
+}
+
+func TestListBetween(t *testing.T) {
+	tests := []struct {
+		name         string
+		from, to     string
+		limit        int
+		want         []string
+		wantRequests int
+		wantErr      string
+	}{
+		{name: "range", from: "c2", to: "c5000000", want: []string{"c3000000", "c4000000", "c5000000"}, wantRequests: 5},
+		{name: "empty range", from: "c3", to: "c3", wantRequests: 2},
+		{name: "limit reached", from: "c1", to: "c5", limit: 2, wantRequests: 4, wantErr: "c1 is not within 2 first-parent commits of c5"},
+		{name: "not an ancestor", from: "c5", to: "c3", wantRequests: 4, wantErr: "c5 is not a first-parent ancestor of c3"},
+		{name: "unknown commit", from: "zz", to: "c5", wantRequests: 1, wantErr: "404"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			client := commitServer(t, &requests)
+
+			commits, _, err := NewProject(client, "myProject").Commits.ListBetween(context.Background(), tt.from, tt.to, tt.limit)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ListBetween() error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("ListBetween() error = %v", err)
+			}
+
+			var got []string
+			for _, commit := range commits {
+				got = append(got, commit.Commit)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListBetween() = %v, want %v", got, tt.want)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("ListBetween() made %d requests, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestCommitService(t *testing.T) {
+	content := "package main\n"
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "GET /projects/parent%2Fchild/commits/a1b2/in":
+			writeJSON(w, IncludedInInfo{Branches: []string{"main"}, Tags: []string{"v1.0"}})
+		case "POST /projects/parent%2Fchild/commits/a1b2/cherrypick":
+			var in CherryPickInput
+			readJSON(t, r, &in)
+			writeJSON(w, ChangeInfo{Number: 42, Branch: in.Destination})
+		case "GET /projects/parent%2Fchild/commits/a1b2/files/":
+			writeJSON(w, map[string]FileInfo{"/COMMIT_MSG": {}, "cmd/main.go": {LinesInserted: 1}})
+		case "GET /projects/parent%2Fchild/commits/a1b2/files/cmd%2Fmain.go/content":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(base64.StdEncoding.EncodeToString([]byte(content))))
+		case "GET /projects/parent%2Fchild/commits/a1b2/files/broken/content":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("not base64!"))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	})
+	commits := NewProject(client, "parent/child").Commits
+	ctx := context.Background()
+
+	in, _, err := commits.GetIncludedIn(ctx, "a1b2")
+	if err != nil || !reflect.DeepEqual(in.Tags, []string{"v1.0"}) {
+		t.Errorf("GetIncludedIn() = %+v, %v, want tag v1.0", in, err)
+	}
+	commit := &Commit{Raw: new(CommitInfo), gerrit: client, project: NewProject(client, "parent/child"), Base: "a1b2"}
+	if old, _, err := commit.GetIncludeIn(ctx); err != nil || !reflect.DeepEqual(old, in) {
+		t.Errorf("GetIncludeIn() = %+v, %v, want the result of GetIncludedIn", old, err)
+	}
+
+	change, _, err := commits.CherryPick(ctx, "a1b2", &CherryPickInput{Destination: "stable"})
+	if err != nil || change.Number != 42 || change.Branch != "stable" {
+		t.Errorf("CherryPick() = %+v, %v, want change 42 on stable", change, err)
+	}
+
+	files, _, err := commit.ListFiles(ctx)
+	if err != nil || len(files) != 2 || files["cmd/main.go"].LinesInserted != 1 {
+		t.Errorf("ListFiles() = %+v, %v", files, err)
+	}
+	got, _, err := commit.GetContent(ctx, "cmd/main.go")
+	if err != nil || string(got) != content {
+		t.Errorf("GetContent() = %q, %v, want %q", got, err, content)
+	}
+	if _, _, err := commit.GetContent(ctx, "broken"); err == nil || !strings.Contains(err.Error(), "decode content of broken") {
+		t.Errorf("GetContent() of invalid base64 error = %v, want a decoding error", err)
+	}
+}
@@ -0,0 +1,207 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DashboardSectionInfo entity contains information about a section in a dashboard.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#dashboard-section-info
+type DashboardSectionInfo struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// DashboardInfo entity contains information about a project dashboard.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#dashboard-info
+type DashboardInfo struct {
+	// ID is the ID of the dashboard, made of the ref and the path of the dashboard file, e.g. "main:closed".
+	ID              string `json:"id"`
+	Project         string `json:"project"`
+	DefiningProject string `json:"defining_project"`
+	Ref             string `json:"ref"`
+	Path            string `json:"path"`
+	Description     string `json:"description,omitempty"`
+
+	// Foreach is a query that is combined with the query of every section.
+	Foreach   string                 `json:"foreach,omitempty"`
+	URL       string                 `json:"url"`
+	IsDefault bool                   `json:"is_default,omitempty"`
+	Title     string                 `json:"title,omitempty"`
+	Sections  []DashboardSectionInfo `json:"sections"`
+}
+
+// DashboardInput entity contains information to create or update a project dashboard.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#dashboard-input
+type DashboardInput struct {
+	// ID is the ID of the dashboard to use as default dashboard.
+	ID            string `json:"id,omitempty"`
+	CommitMessage string `json:"commit_message,omitempty"`
+}
+
+// DashboardPopulateOptions specifies the parameters to DashboardService.Populate.
+type DashboardPopulateOptions struct {
+	// Limit is the maximum number of changes per section. The server default applies when 0.
+	Limit int
+
+	// AdditionalFields are the o= options the changes are retrieved with.
+	AdditionalFields []string
+}
+
+// DashboardSectionResult is a section of a dashboard with the changes matching its query.
+type DashboardSectionResult struct {
+	DashboardSectionInfo
+
+	// EffectiveQuery is the query that was run: the section query combined with the dashboard's foreach query,
+	// with ${project} replaced by the project name.
+	EffectiveQuery string
+
+	Changes []ChangeInfo
+
+	// MoreChanges is true when more changes match the query than were returned.
+	MoreChanges bool
+
+	// Err is set when the query of the section failed.
+	Err error
+}
+
+// DashboardResult is a dashboard populated with the changes of its sections.
+type DashboardResult struct {
+	Dashboard DashboardInfo
+	Sections  []DashboardSectionResult
+}
+
+type DashboardService struct {
+	gerrit  *Gerrit
+	project *Project
+}
+
+type IDashboardService interface {
+	List(ctx context.Context) (*[]DashboardInfo, *http.Response, error)
+	Get(ctx context.Context, dashboardID string) (*DashboardInfo, *http.Response, error)
+	Create(ctx context.Context, dashboardID string, input *DashboardInput) (*DashboardInfo, *http.Response, error)
+	Delete(ctx context.Context, dashboardID string) (bool, *http.Response, error)
+	SetDefault(ctx context.Context, input *DashboardInput) (*DashboardInfo, *http.Response, error)
+	Populate(ctx context.Context, dashboardID string, opt *DashboardPopulateOptions) (*DashboardResult, *http.Response, error)
+}
+
+// List lists the custom dashboards of the project.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#list-dashboards
+func (s *DashboardService) List(ctx context.Context) (*[]DashboardInfo, *http.Response, error) {
+	u := fmt.Sprintf("projects/%s/dashboards/", url.QueryEscape(s.project.Base))
+
+	v := &[]DashboardInfo{}
+	resp, err := s.gerrit.Requester.Call(ctx, "GET", u, nil, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// Get retrieves a project dashboard. The dashboard can be defined on the project or inherited from a parent project.
+// The ID "default" retrieves the default dashboard of the project.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-dashboard
+func (s *DashboardService) Get(ctx context.Context, dashboardID string) (*DashboardInfo, *http.Response, error) {
+	v := new(DashboardInfo)
+
+	resp, err := s.gerrit.Requester.Call(ctx, "GET", s.dashboardURL(dashboardID), nil, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// Create creates or updates a project dashboard.
+// Gerrit currently only supports this for the "default" dashboard, see SetDefault;
+// other dashboards are defined by files in the refs/meta/dashboards/* branches.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#set-dashboard
+func (s *DashboardService) Create(ctx context.Context, dashboardID string, input *DashboardInput) (*DashboardInfo, *http.Response, error) {
+	v := new(DashboardInfo)
+
+	resp, err := s.gerrit.Requester.Call(ctx, "PUT", s.dashboardURL(dashboardID), input, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// Delete deletes a project dashboard.
+// Gerrit currently only supports this for the "default" dashboard, which unsets the default dashboard of the project.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#delete-dashboard
+func (s *DashboardService) Delete(ctx context.Context, dashboardID string) (bool, *http.Response, error) {
+	resp, err := s.gerrit.Requester.Call(ctx, "DELETE", s.dashboardURL(dashboardID), nil, nil)
+	if err != nil {
+		return false, resp, err
+	}
+	return true, resp, nil
+}
+
+// SetDefault sets the default dashboard of the project to the dashboard with the ID of the input.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#set-dashboard
+func (s *DashboardService) SetDefault(ctx context.Context, input *DashboardInput) (*DashboardInfo, *http.Response, error) {
+	return s.Create(ctx, "default", input)
+}
+
+// Populate retrieves a dashboard and runs the query of every section with ChangeService.Query.
+// A failing section does not prevent the others from being populated; its error is reported in the section.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/user-dashboards.html
+func (s *DashboardService) Populate(ctx context.Context, dashboardID string, opt *DashboardPopulateOptions) (*DashboardResult, *http.Response, error) {
+	dashboard, resp, err := s.Get(ctx, dashboardID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	o := DashboardPopulateOptions{}
+	if opt != nil {
+		o = *opt
+	}
+
+	result := &DashboardResult{Dashboard: *dashboard}
+	for _, section := range dashboard.Sections {
+		sr := DashboardSectionResult{
+			DashboardSectionInfo: section,
+			EffectiveQuery:       dashboardQuery(dashboard, section, s.project.Base),
+		}
+
+		query := &QueryChangeOptions{}
+		query.Query = []string{sr.EffectiveQuery}
+		query.Limit = o.Limit
+		query.AdditionalFields = o.AdditionalFields
+
+		changes, _, err := s.gerrit.Changes.Query(ctx, query)
+		if err != nil {
+			sr.Err = err
+		} else {
+			sr.Changes = *changes
+			sr.MoreChanges = len(sr.Changes) > 0 && sr.Changes[len(sr.Changes)-1].MoreChanges
+		}
+		result.Sections = append(result.Sections, sr)
+	}
+	return result, resp, nil
+}
+
+// dashboardQuery combines the query of a section with the foreach query of the dashboard
+// and replaces the ${project} placeholder, like the web UI does.
+func dashboardQuery(dashboard *DashboardInfo, section DashboardSectionInfo, project string) string {
+	q := section.Query
+	if dashboard.Foreach != "" {
+		q = "(" + dashboard.Foreach + ") (" + q + ")"
+	}
+	return strings.ReplaceAll(q, "${project}", project)
+}
+
+func (s *DashboardService) dashboardURL(dashboardID string) string {
+	return fmt.Sprintf("projects/%s/dashboards/%s", url.QueryEscape(s.project.Base), url.QueryEscape(dashboardID))
+}
@@ -0,0 +1,119 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestDashboardQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		foreach string
+		query   string
+		want    string
+	}{
+		{"section only", "", "is:open project:${project}", "is:open project:parent/child"},
+		{"with foreach", "project:${project}", "is:open", "(project:parent/child) (is:open)"},
+		{
+			// Without the parentheses, the OR of the section would bind looser than the foreach query.
+			"operators are grouped",
+			"project:${project} -is:wip",
+			"owner:self OR reviewer:self",
+			"(project:parent/child -is:wip) (owner:self OR reviewer:self)",
+		},
+		{"placeholder repeated", "", "project:${project} OR parentproject:${project}", "project:parent/child OR parentproject:parent/child"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dashboard := &DashboardInfo{Foreach: tt.foreach}
+			if got := dashboardQuery(dashboard, DashboardSectionInfo{Query: tt.query}, "parent/child"); got != tt.want {
+				t.Errorf("dashboardQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDashboardPopulate(t *testing.T) {
+	dashboard := DashboardInfo{
+		ID:      "main:review",
+		Foreach: "project:${project}",
+		Sections: []DashboardSectionInfo{
+			{Name: "Mine", Query: "owner:self"},
+			{Name: "Broken", Query: "label:Verified="},
+			{Name: "Empty", Query: "is:merged age:1d"},
+		},
+	}
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "GET /projects/parent%2Fchild/dashboards/main%3Areview":
+			writeJSON(w, dashboard)
+		case "GET /changes/":
+			if r.URL.Query().Get("n") != "2" || r.URL.Query().Get("o") != "LABELS" {
+				t.Errorf("query options = %s, want the limit and fields", r.URL.RawQuery)
+			}
+			switch r.URL.Query().Get("q") {
+			case "(project:parent/child) (owner:self)":
+				writeJSON(w, []ChangeInfo{{Number: 1}, {Number: 2, MoreChanges: true}})
+			case "(project:parent/child) (label:Verified=)":
+				http.Error(w, "Invalid query", http.StatusBadRequest)
+			case "(project:parent/child) (is:merged age:1d)":
+				writeJSON(w, []ChangeInfo{})
+			default:
+				t.Errorf("unexpected query %q", r.URL.Query().Get("q"))
+			}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	})
+
+	result, _, err := NewProject(client, "parent/child").Dashboards.Populate(context.Background(), "main:review",
+		&DashboardPopulateOptions{Limit: 2, AdditionalFields: []string{"LABELS"}})
+	if err != nil {
+		t.Fatalf("Populate() error = %v", err)
+	}
+	if result.Dashboard.ID != "main:review" || len(result.Sections) != 3 {
+		t.Fatalf("Populate() = %+v, want the three sections of the dashboard", result)
+	}
+	mine, broken, empty := result.Sections[0], result.Sections[1], result.Sections[2]
+	if mine.Err != nil || len(mine.Changes) != 2 || !mine.MoreChanges || mine.EffectiveQuery != "(project:parent/child) (owner:self)" {
+		t.Errorf("section Mine = %+v, want two changes and more", mine)
+	}
+	if broken.Err == nil || broken.Changes != nil {
+		t.Errorf("section Broken = %+v, want the query error", broken)
+	}
+	if empty.Err != nil || len(empty.Changes) != 0 || empty.MoreChanges {
+		t.Errorf("section Empty = %+v, want no changes", empty)
+	}
+}
+
+func TestDashboardService(t *testing.T) {
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "GET /projects/myProject/dashboards/":
+			writeJSON(w, []DashboardInfo{{ID: "main:closed"}, {ID: "main:review", IsDefault: true}})
+		case "PUT /projects/myProject/dashboards/default":
+			var in DashboardInput
+			readJSON(t, r, &in)
+			writeJSON(w, DashboardInfo{ID: in.ID, IsDefault: true})
+		case "DELETE /projects/myProject/dashboards/default":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	})
+	dashboards := NewProject(client, "myProject").Dashboards
+	ctx := context.Background()
+
+	list, _, err := dashboards.List(ctx)
+	if err != nil || len(*list) != 2 {
+		t.Errorf("List() = %+v, %v, want two dashboards", list, err)
+	}
+	def, _, err := dashboards.SetDefault(ctx, &DashboardInput{ID: "main:closed", CommitMessage: "Default to closed"})
+	if err != nil || def.ID != "main:closed" || !def.IsDefault {
+		t.Errorf("SetDefault() = %+v, %v, want main:closed as default", def, err)
+	}
+	if ok, _, err := dashboards.Delete(ctx, "default"); !ok || err != nil {
+		t.Errorf("Delete() = %v, %v, want true", ok, err)
+	}
+}
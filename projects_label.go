@@ -0,0 +1,230 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// LabelDefinitionInfo entity describes a label.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#label-definition-info
+type LabelDefinitionInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	ProjectName string `json:"project_name,omitempty"`
+
+	// Function is the function of the label, e.g. "NoBlock", "NoOp" or "PatchSetLock".
+	Function string `json:"function,omitempty"`
+
+	// Values maps the values of the label, e.g. "+1" or " 0", to their descriptions.
+	Values       map[string]string `json:"values"`
+	DefaultValue int               `json:"default_value,omitempty"`
+
+	// Branches are the refs the label applies to. The label applies to all branches when empty.
+	Branches []string `json:"branches,omitempty"`
+
+	CanOverride        bool   `json:"can_override,omitempty"`
+	CopyCondition      string `json:"copy_condition,omitempty"`
+	AllowPostSubmit    bool   `json:"allow_post_submit,omitempty"`
+	IgnoreSelfApproval bool   `json:"ignore_self_approval,omitempty"`
+}
+
+// LabelDefinitionInput entity describes a label. Unset fields are left as they are when updating a label.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#label-definition-input
+type LabelDefinitionInput struct {
+	// CommitMessage is the message of the commit to refs/meta/config.
+	CommitMessage string `json:"commit_message,omitempty"`
+
+	// Name is the new name of the label when renaming it.
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Function    string `json:"function,omitempty"`
+
+	Values       map[string]string `json:"values,omitempty"`
+	DefaultValue *int              `json:"default_value,omitempty"`
+	Branches     []string          `json:"branches,omitempty"`
+
+	CanOverride        *bool  `json:"can_override,omitempty"`
+	CopyCondition      string `json:"copy_condition,omitempty"`
+	UnsetCopyCondition *bool  `json:"unset_copy_condition,omitempty"`
+	AllowPostSubmit    *bool  `json:"allow_post_submit,omitempty"`
+	IgnoreSelfApproval *bool  `json:"ignore_self_approval,omitempty"`
+}
+
+// LabelOptions specifies the parameters to LabelService.List.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#list-labels
+type LabelOptions struct {
+	// Inherited includes the labels inherited from parent projects, in the order of the project hierarchy.
+	Inherited bool `url:"inherited,omitempty"`
+}
+
+// DeleteLabelInput entity contains information for deleting a label definition.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#delete-label-input
+type DeleteLabelInput struct {
+	// CommitMessage is the message of the commit to refs/meta/config.
+	CommitMessage string `json:"commit_message,omitempty"`
+}
+
+// BatchLabelInput entity contains information for batch updating label definitions in a project.
+// Deletions are applied first, then creations and then updates, all in a single commit.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#batch-label-input
+type BatchLabelInput struct {
+	// CommitMessage is the message of the commit to refs/meta/config.
+	CommitMessage string `json:"commit_message,omitempty"`
+
+	// Delete are the names of the labels to delete.
+	Delete []string `json:"delete,omitempty"`
+
+	// Create are the labels to create. Their Name is mandatory.
+	Create []LabelDefinitionInput `json:"create,omitempty"`
+
+	// Update maps the names of the labels to update to their new definitions.
+	Update map[string]LabelDefinitionInput `json:"update,omitempty"`
+}
+
+type LabelService struct {
+	gerrit  *Gerrit
+	project *Project
+}
+
+type ILabelService interface {
+	List(ctx context.Context, opt *LabelOptions) (*[]LabelDefinitionInfo, *http.Response, error)
+	Get(ctx context.Context, labelName string) (*LabelDefinitionInfo, *http.Response, error)
+	Create(ctx context.Context, labelName string, input *LabelDefinitionInput) (*LabelDefinitionInfo, *http.Response, error)
+	Update(ctx context.Context, labelName string, input *LabelDefinitionInput) (*LabelDefinitionInfo, *http.Response, error)
+	Delete(ctx context.Context, labelName string, input *DeleteLabelInput) (bool, *http.Response, error)
+	Batch(ctx context.Context, input *BatchLabelInput) (bool, *http.Response, error)
+	BatchReview(ctx context.Context, input *BatchLabelInput) (*ChangeInfo, *http.Response, error)
+}
+
+// List lists the labels that are defined in the project.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#list-labels
+func (s *LabelService) List(ctx context.Context, opt *LabelOptions) (*[]LabelDefinitionInfo, *http.Response, error) {
+	u := fmt.Sprintf("projects/%s/labels/", url.QueryEscape(s.project.Base))
+
+	v := &[]LabelDefinitionInfo{}
+	resp, err := s.gerrit.Requester.Call(ctx, "GET", u, opt, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// Get retrieves the definition of a label that is defined in the project.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-label
+func (s *LabelService) Get(ctx context.Context, labelName string) (*LabelDefinitionInfo, *http.Response, error) {
+	v := new(LabelDefinitionInfo)
+
+	resp, err := s.gerrit.Requester.Call(ctx, "GET", s.labelURL(labelName), nil, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// Create creates a new label definition in the project. Values is mandatory.
+// It fails with 412 Precondition Failed when the label already exists, rather than updating it.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#create-label
+func (s *LabelService) Create(ctx context.Context, labelName string, input *LabelDefinitionInput) (*LabelDefinitionInfo, *http.Response, error) {
+	req, err := s.gerrit.Requester.NewRequest(ctx, "PUT", s.labelURL(labelName), input)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The same PUT updates an existing label, unless the label must not exist yet.
+	req.Header.Set("If-None-Match", "*")
+
+	v := new(LabelDefinitionInfo)
+	resp, err := s.gerrit.Requester.Do(req, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// Update updates the definition of a label that is defined in the project.
+// Only the fields set in the input are updated; Name renames the label.
+// It fails with 404 Not Found when the label does not exist, rather than creating it.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#set-label
+func (s *LabelService) Update(ctx context.Context, labelName string, input *LabelDefinitionInput) (*LabelDefinitionInfo, *http.Response, error) {
+	if _, resp, err := s.Get(ctx, labelName); err != nil {
+		return nil, resp, err
+	}
+
+	v := new(LabelDefinitionInfo)
+	resp, err := s.gerrit.Requester.Call(ctx, "PUT", s.labelURL(labelName), input, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// Delete deletes the definition of a label that is defined in the project. The input is optional.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#delete-label
+func (s *LabelService) Delete(ctx context.Context, labelName string, input *DeleteLabelInput) (bool, *http.Response, error) {
+	req, err := s.gerrit.Requester.NewRequest(ctx, "DELETE", s.labelURL(labelName), nil)
+	if err != nil {
+		return false, nil, err
+	}
+	if input != nil {
+		// NewRequest only sends bodies with POST and PUT, but the commit message goes in the body of the DELETE.
+		body, err := json.Marshal(input)
+		if err != nil {
+			return false, nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.gerrit.Requester.Do(req, nil)
+	if err != nil {
+		return false, resp, err
+	}
+	return true, resp, nil
+}
+
+// Batch deletes, creates and updates label definitions of the project in a single commit to refs/meta/config.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#batch-update-labels
+func (s *LabelService) Batch(ctx context.Context, input *BatchLabelInput) (bool, *http.Response, error) {
+	u := fmt.Sprintf("projects/%s/labels/", url.QueryEscape(s.project.Base))
+
+	resp, err := s.gerrit.Requester.Call(ctx, "POST", u, input, nil)
+	if err != nil {
+		return false, resp, err
+	}
+	return true, resp, nil
+}
+
+// BatchReview creates a change for review that applies the label definition updates to refs/meta/config,
+// instead of applying them directly like Batch.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#create-labels-change
+func (s *LabelService) BatchReview(ctx context.Context, input *BatchLabelInput) (*ChangeInfo, *http.Response, error) {
+	v := new(ChangeInfo)
+	u := fmt.Sprintf("projects/%s/labels:review", url.QueryEscape(s.project.Base))
+
+	resp, err := s.gerrit.Requester.Call(ctx, "POST", u, input, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+func (s *LabelService) labelURL(labelName string) string {
+	return fmt.Sprintf("projects/%s/labels/%s", url.QueryEscape(s.project.Base), url.QueryEscape(labelName))
+}
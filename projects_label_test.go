@@ -0,0 +1,100 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestLabelService(t *testing.T) {
+	verified := LabelDefinitionInfo{Name: "Verified", Function: "MaxWithBlock", Values: map[string]string{"-1": "Fails", " 0": "No score", "+1": "Verified"}}
+	var requests []string
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		request := r.Method + " " + r.URL.EscapedPath()
+		requests = append(requests, request)
+		switch request {
+		case "GET /projects/parent%2Fchild/labels/":
+			if r.URL.RawQuery != "inherited=true" {
+				t.Errorf("query = %q, want inherited=true", r.URL.RawQuery)
+			}
+			writeJSON(w, []LabelDefinitionInfo{verified})
+		case "GET /projects/parent%2Fchild/labels/Verified":
+			writeJSON(w, verified)
+		case "GET /projects/parent%2Fchild/labels/Missing":
+			http.Error(w, "Not found: Missing", http.StatusNotFound)
+		case "PUT /projects/parent%2Fchild/labels/Verified":
+			var in LabelDefinitionInput
+			readJSON(t, r, &in)
+			if r.Header.Get("If-None-Match") != "" || in.Function != "NoBlock" {
+				t.Errorf("update = %+v with If-None-Match %q, want an update of the function", in, r.Header.Get("If-None-Match"))
+			}
+			writeJSON(w, verified)
+		case "PUT /projects/parent%2Fchild/labels/Code-Review":
+			if r.Header.Get("If-None-Match") != "*" {
+				t.Errorf("If-None-Match = %q, want * for creations", r.Header.Get("If-None-Match"))
+			}
+			w.WriteHeader(http.StatusCreated)
+			writeJSON(w, LabelDefinitionInfo{Name: "Code-Review"})
+		case "DELETE /projects/parent%2Fchild/labels/Verified":
+			var in DeleteLabelInput
+			readJSON(t, r, &in)
+			if in.CommitMessage != "Drop Verified" || r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("delete input = %+v, want the commit message as JSON", in)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "DELETE /projects/parent%2Fchild/labels/Code-Review":
+			if r.ContentLength != 0 {
+				t.Errorf("delete without input has a body of %d bytes", r.ContentLength)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /projects/parent%2Fchild/labels/":
+			var in BatchLabelInput
+			readJSON(t, r, &in)
+			if !reflect.DeepEqual(in.Delete, []string{"Verified"}) || len(in.Create) != 1 {
+				t.Errorf("batch input = %+v", in)
+			}
+			writeJSON(w, "")
+		case "POST /projects/parent%2Fchild/labels:review":
+			writeJSON(w, ChangeInfo{Number: 42, Branch: "refs/meta/config"})
+		default:
+			t.Errorf("unexpected request %s", request)
+		}
+	})
+	labels := NewProject(client, "parent/child").Labels
+	ctx := context.Background()
+
+	list, _, err := labels.List(ctx, &LabelOptions{Inherited: true})
+	if err != nil || len(*list) != 1 || (*list)[0].Name != "Verified" {
+		t.Errorf("List() = %+v, %v, want Verified", list, err)
+	}
+	if _, _, err := labels.Create(ctx, "Code-Review", &LabelDefinitionInput{Values: map[string]string{"+1": "LGTM"}}); err != nil {
+		t.Errorf("Create() error = %v", err)
+	}
+	if _, _, err := labels.Update(ctx, "Verified", &LabelDefinitionInput{Function: "NoBlock"}); err != nil {
+		t.Errorf("Update() error = %v", err)
+	}
+	if _, resp, err := labels.Update(ctx, "Missing", &LabelDefinitionInput{Function: "NoBlock"}); err == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Update() of a missing label error = %v, want 404 Not Found", err)
+	}
+	if ok, _, err := labels.Delete(ctx, "Verified", &DeleteLabelInput{CommitMessage: "Drop Verified"}); !ok || err != nil {
+		t.Errorf("Delete() = %v, %v, want true", ok, err)
+	}
+	if ok, _, err := labels.Delete(ctx, "Code-Review", nil); !ok || err != nil {
+		t.Errorf("Delete() without input = %v, %v, want true", ok, err)
+	}
+	batch := &BatchLabelInput{Delete: []string{"Verified"}, Create: []LabelDefinitionInput{{Name: "Code-Review"}}}
+	if ok, _, err := labels.Batch(ctx, batch); !ok || err != nil {
+		t.Errorf("Batch() = %v, %v, want true", ok, err)
+	}
+	if change, _, err := labels.BatchReview(ctx, batch); err != nil || change.Number != 42 {
+		t.Errorf("BatchReview() = %+v, %v, want change 42", change, err)
+	}
+
+	// Updating a missing label must not create it.
+	for _, request := range requests {
+		if request == "PUT /projects/parent%2Fchild/labels/Missing" {
+			t.Error("Update() of a missing label sent the PUT creating it")
+		}
+	}
+}
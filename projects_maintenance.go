@@ -0,0 +1,187 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+)
+
+// GarbageCollectionInput entity contains information to run the Git garbage collection.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#gc-input
+type GarbageCollectionInput struct {
+	// ShowProgress includes progress information in the output of a synchronous run.
+	ShowProgress bool `json:"show_progress,omitempty"`
+
+	// Aggressive runs an aggressive garbage collection.
+	Aggressive bool `json:"aggressive,omitempty"`
+
+	// Async schedules the garbage collection in the background instead of waiting for it to complete.
+	Async bool `json:"async,omitempty"`
+}
+
+// GarbageCollectionResult is the outcome of Project.RunGC.
+type GarbageCollectionResult struct {
+	// Output is the output of the garbage collection, or the confirmation that it was scheduled when run asynchronously.
+	Output string
+
+	// TaskID is the ID of the background task of an asynchronous run, to be tracked with ConfigService.GetTask
+	// or ConfigService.WaitForTask.
+	TaskID string
+}
+
+// RepositoryStatisticsInfo entity contains information about a Git repository.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#repository-statistics-info
+type RepositoryStatisticsInfo struct {
+	NumberOfLooseObjects  int   `json:"number_of_loose_objects"`
+	NumberOfLooseRefs     int   `json:"number_of_loose_refs"`
+	NumberOfPackFiles     int   `json:"number_of_pack_files"`
+	NumberOfPackedObjects int   `json:"number_of_packed_objects"`
+	NumberOfPackedRefs    int   `json:"number_of_packed_refs"`
+	SizeOfLooseObjects    int64 `json:"size_of_loose_objects"`
+	SizeOfPackedObjects   int64 `json:"size_of_packed_objects"`
+}
+
+// IndexProjectInput entity contains information for indexing a project.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#index-project-input
+type IndexProjectInput struct {
+	// IndexChildren indexes the child projects too, recursively.
+	IndexChildren bool `json:"index_children,omitempty"`
+
+	// Async schedules the indexing in the background instead of waiting for it to complete.
+	Async bool `json:"async,omitempty"`
+}
+
+// BanInput entity contains information for banning commits in a project.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#ban-input
+type BanInput struct {
+	Commits []string `json:"commits"`
+	Reason  string   `json:"reason,omitempty"`
+}
+
+// BanResultInfo entity describes the result of banning commits.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#ban-result-info
+type BanResultInfo struct {
+	NewlyBanned   []string `json:"newly_banned,omitempty"`
+	AlreadyBanned []string `json:"already_banned,omitempty"`
+	Ignored       []string `json:"ignored,omitempty"`
+}
+
+// ProjectGCResult reports the outcome of the garbage collection of one project by ProjectService.RunGC.
+type ProjectGCResult struct {
+	ProjectOperationResult
+
+	GC *GarbageCollectionResult
+}
+
+// RunGC runs the Git garbage collection for the repository of a project.
+// When run asynchronously, the ID of the background task is taken from the Location header of the response.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#run-gc
+func (p *Project) RunGC(ctx context.Context, input *GarbageCollectionInput) (*GarbageCollectionResult, *http.Response, error) {
+	v := new(string)
+	u := fmt.Sprintf("projects/%s/gc", url.QueryEscape(p.Base))
+
+	resp, err := p.gerrit.Requester.Call(ctx, "POST", u, input, v)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	result := &GarbageCollectionResult{Output: *v}
+	if location := resp.Header.Get("Location"); resp.StatusCode == http.StatusAccepted && location != "" {
+		result.TaskID = path.Base(location)
+	}
+	return result, resp, nil
+}
+
+// GetStatistics returns statistics of the Git repository of a project.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-repository-statistics
+func (p *Project) GetStatistics(ctx context.Context) (*RepositoryStatisticsInfo, *http.Response, error) {
+	v := new(RepositoryStatisticsInfo)
+	u := fmt.Sprintf("projects/%s/statistics.git", url.QueryEscape(p.Base))
+
+	resp, err := p.gerrit.Requester.Call(ctx, "GET", u, nil, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// Index adds or updates the project, and optionally its child projects, in the secondary index.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#index
+func (p *Project) Index(ctx context.Context, input *IndexProjectInput) (bool, *http.Response, error) {
+	u := fmt.Sprintf("projects/%s/index", url.QueryEscape(p.Base))
+
+	resp, err := p.gerrit.Requester.Call(ctx, "POST", u, input, nil)
+	if err != nil {
+		return false, resp, err
+	}
+	return true, resp, nil
+}
+
+// IndexChanges adds or updates all changes of the project in the secondary index. Indexing runs in the background.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#index.changes
+func (p *Project) IndexChanges(ctx context.Context) (bool, *http.Response, error) {
+	u := fmt.Sprintf("projects/%s/index.changes", url.QueryEscape(p.Base))
+
+	resp, err := p.gerrit.Requester.Call(ctx, "POST", u, nil, nil)
+	if err != nil {
+		return false, resp, err
+	}
+	return true, resp, nil
+}
+
+// BanCommits marks commits as banned for the project, so that they cannot be pushed again.
+// A banned commit that exists in the repository is not removed by this; it is ignored instead.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#ban-commit
+func (p *Project) BanCommits(ctx context.Context, input *BanInput) (*BanResultInfo, *http.Response, error) {
+	v := new(BanResultInfo)
+	u := fmt.Sprintf("projects/%s/ban", url.QueryEscape(p.Base))
+
+	resp, err := p.gerrit.Requester.Call(ctx, "PUT", u, input, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// RunGC runs the Git garbage collection for every project matching opt, with at most concurrency runs at a time.
+// A failure for one project does not prevent the others from being collected; results are sorted by project name.
+// Projects that were not started because the context was done are reported as skipped.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#run-gc
+func (s *ProjectService) RunGC(ctx context.Context, opt *ProjectOptions, input *GarbageCollectionInput, concurrency int) ([]ProjectGCResult, *http.Response, error) {
+	projects, resp, err := s.List(ctx, opt)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	names := make([]string, 0, len(projects))
+	for name := range projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make([]ProjectGCResult, len(names))
+	for i, name := range names {
+		results[i].Project = name
+	}
+	forEachProject(ctx, len(results), concurrency,
+		func(i int) *ProjectOperationResult { return &results[i].ProjectOperationResult },
+		func(i int) {
+			results[i].GC, _, results[i].Err = NewProject(s.gerrit, results[i].Project).RunGC(ctx, input)
+		})
+
+	return results, resp, nil
+}
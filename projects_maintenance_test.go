@@ -0,0 +1,191 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProjectRunGC(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  GarbageCollectionInput
+		status int
+		want   GarbageCollectionResult
+	}{
+		{
+			name:   "synchronous",
+			input:  GarbageCollectionInput{ShowProgress: true},
+			status: http.StatusOK,
+			want:   GarbageCollectionResult{Output: "collecting garbage for \"myProject\":\ndone."},
+		},
+		{
+			name:   "asynchronous",
+			input:  GarbageCollectionInput{Async: true, Aggressive: true},
+			status: http.StatusAccepted,
+			want:   GarbageCollectionResult{Output: "Garbage collection was scheduled", TaskID: "3a8b0f7c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method+" "+r.URL.Path != "POST /projects/myProject/gc" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				var in GarbageCollectionInput
+				readJSON(t, r, &in)
+				if in != tt.input {
+					t.Errorf("input = %+v, want %+v", in, tt.input)
+				}
+				if tt.status == http.StatusAccepted {
+					w.Header().Set("Location", "http://"+r.Host+"/config/server/tasks/3a8b0f7c")
+				}
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.want.Output + "\n"))
+			})
+
+			got, _, err := NewProject(client, "myProject").RunGC(context.Background(), &tt.input)
+			if err != nil {
+				t.Fatalf("RunGC() error = %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("RunGC() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectMaintenance(t *testing.T) {
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "GET /projects/parent%2Fchild/statistics.git":
+			writeJSON(w, RepositoryStatisticsInfo{NumberOfPackFiles: 2, SizeOfPackedObjects: 1 << 33})
+		case "POST /projects/parent%2Fchild/index":
+			var in IndexProjectInput
+			readJSON(t, r, &in)
+			if !in.IndexChildren || !in.Async {
+				t.Errorf("index input = %+v, want children indexed asynchronously", in)
+			}
+			w.WriteHeader(http.StatusAccepted)
+		case "POST /projects/parent%2Fchild/index.changes":
+			w.WriteHeader(http.StatusAccepted)
+		case "PUT /projects/parent%2Fchild/ban":
+			var in BanInput
+			readJSON(t, r, &in)
+			writeJSON(w, BanResultInfo{NewlyBanned: in.Commits[:1], AlreadyBanned: in.Commits[1:]})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	})
+	project := NewProject(client, "parent/child")
+	ctx := context.Background()
+
+	stats, _, err := project.GetStatistics(ctx)
+	if err != nil || stats.NumberOfPackFiles != 2 || stats.SizeOfPackedObjects != 1<<33 {
+		t.Errorf("GetStatistics() = %+v, %v", stats, err)
+	}
+	if ok, _, err := project.Index(ctx, &IndexProjectInput{IndexChildren: true, Async: true}); !ok || err != nil {
+		t.Errorf("Index() = %v, %v, want true", ok, err)
+	}
+	if ok, _, err := project.IndexChanges(ctx); !ok || err != nil {
+		t.Errorf("IndexChanges() = %v, %v, want true", ok, err)
+	}
+	ban, _, err := project.BanCommits(ctx, &BanInput{Commits: []string{"a1", "b2"}, Reason: "leaked secret"})
+	if err != nil || !reflect.DeepEqual(ban.NewlyBanned, []string{"a1"}) || !reflect.DeepEqual(ban.AlreadyBanned, []string{"b2"}) {
+		t.Errorf("BanCommits() = %+v, %v", ban, err)
+	}
+}
+
+func TestProjectServiceRunGC(t *testing.T) {
+	var running, maxRunning int32
+	var mu sync.Mutex
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/projects/" {
+			if r.URL.Query().Get("p") != "platform/" {
+				t.Errorf("query = %s, want the prefix of the projects", r.URL.RawQuery)
+			}
+			writeJSON(w, map[string]ProjectInfo{"platform/c": {}, "platform/a": {}, "platform/b": {}, "platform/d": {}})
+			return
+		}
+
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		mu.Lock()
+		if n > maxRunning {
+			maxRunning = n
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+
+		if r.URL.EscapedPath() == "/projects/platform%2Fb/gc" {
+			http.Error(w, "repository is locked", http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte("done.\n"))
+	})
+
+	results, _, err := client.Projects.RunGC(context.Background(), &ProjectOptions{Prefix: "platform/"}, &GarbageCollectionInput{}, 2)
+	if err != nil {
+		t.Fatalf("RunGC() error = %v", err)
+	}
+
+	var got []string
+	for _, result := range results {
+		outcome := "done"
+		if result.Err != nil {
+			outcome = "failed"
+		} else if result.GC == nil || result.GC.Output != "done." {
+			outcome = "no output"
+		}
+		got = append(got, result.Project+" "+outcome)
+	}
+	want := []string{"platform/a done", "platform/b failed", "platform/c done", "platform/d done"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RunGC() = %v, want %v", got, want)
+	}
+	if maxRunning > 2 {
+		t.Errorf("RunGC() ran %d collections at a time, want at most 2", maxRunning)
+	}
+}
+
+func TestProjectServiceRunGCCancelled(t *testing.T) {
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/" {
+			t.Errorf("unexpected request %s after the context was cancelled", r.URL.Path)
+			return
+		}
+		writeJSON(w, map[string]ProjectInfo{"a": {}, "b": {}})
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// The project list is retrieved by the time the first collection would start.
+	client.Requester.client.Transport = cancelAfterTransport{next: client.Requester.client.Transport, cancel: cancel}
+
+	results, _, err := client.Projects.RunGC(ctx, nil, nil, 1)
+	if err != nil {
+		t.Fatalf("RunGC() error = %v", err)
+	}
+	for _, result := range results {
+		if !result.Skipped || !strings.Contains(result.Reason, "canceled") {
+			t.Errorf("result for %s = %+v, want skipped because of the cancellation", result.Project, result)
+		}
+	}
+}
+
+// cancelAfterTransport cancels a context once the first request completed.
+type cancelAfterTransport struct {
+	next   http.RoundTripper
+	cancel context.CancelFunc
+}
+
+func (t cancelAfterTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(r)
+	t.cancel()
+	return resp, err
+}
@@ -0,0 +1,208 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SubmitRequirementInfo entity describes a submit requirement.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#submit-requirement-info
+type SubmitRequirementInfo struct {
+	Name                         string `json:"name"`
+	Description                  string `json:"description,omitempty"`
+	ApplicabilityExpression      string `json:"applicability_expression,omitempty"`
+	SubmittabilityExpression     string `json:"submittability_expression"`
+	OverrideExpression           string `json:"override_expression,omitempty"`
+	AllowOverrideInChildProjects bool   `json:"allow_override_in_child_projects,omitempty"`
+}
+
+// SubmitRequirementOptions specifies the parameters to SubmitRequirementService.List.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#list-submit-requirements
+type SubmitRequirementOptions struct {
+	// Inherited includes the submit requirements inherited from parent projects, in the order of the project hierarchy.
+	Inherited bool `url:"inherited,omitempty"`
+}
+
+// SubmitRequirementDryRunOptions specifies the parameters to SubmitRequirementService.DryRun.
+type SubmitRequirementDryRunOptions struct {
+	// Query is added to the search for the sample changes, e.g. "branch:main". Only open changes of the project are searched.
+	Query string
+
+	// Limit is the maximum number of changes the requirement is checked against. Defaults to 10.
+	Limit int
+}
+
+// SubmitRequirementCheck is the result of checking a submit requirement against a single change.
+type SubmitRequirementCheck struct {
+	Change ChangeInfo
+	Result *SubmitRequirementResultInfo
+
+	// Err is set when the requirement could not be checked against the change.
+	Err error
+}
+
+// SubmitRequirementDryRun is the result of checking a submit requirement against a sample of open changes.
+type SubmitRequirementDryRun struct {
+	Requirement SubmitRequirementInput
+	Checks      []SubmitRequirementCheck
+}
+
+// Counts returns the number of changes per status of the requirement, e.g. "SATISFIED" or "NOT_APPLICABLE".
+// Changes that could not be checked are counted as "ERROR".
+func (d *SubmitRequirementDryRun) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, check := range d.Checks {
+		if check.Err != nil || check.Result == nil {
+			counts["ERROR"]++
+			continue
+		}
+		counts[check.Result.Status]++
+	}
+	return counts
+}
+
+// Errors returns the checks that failed or whose expressions could not be evaluated,
+// which usually means the requirement is invalid and should not be saved.
+func (d *SubmitRequirementDryRun) Errors() []SubmitRequirementCheck {
+	var errs []SubmitRequirementCheck
+	for _, check := range d.Checks {
+		if check.Err != nil || check.Result == nil || check.Result.Status == "ERROR" {
+			errs = append(errs, check)
+		}
+	}
+	return errs
+}
+
+type SubmitRequirementService struct {
+	gerrit  *Gerrit
+	project *Project
+}
+
+type ISubmitRequirementService interface {
+	List(ctx context.Context, opt *SubmitRequirementOptions) (*[]SubmitRequirementInfo, *http.Response, error)
+	Get(ctx context.Context, name string) (*SubmitRequirementInfo, *http.Response, error)
+	Create(ctx context.Context, name string, input *SubmitRequirementInput) (*SubmitRequirementInfo, *http.Response, error)
+	Update(ctx context.Context, name string, input *SubmitRequirementInput) (*SubmitRequirementInfo, *http.Response, error)
+	Delete(ctx context.Context, name string) (bool, *http.Response, error)
+	DryRun(ctx context.Context, input *SubmitRequirementInput, opt *SubmitRequirementDryRunOptions) (*SubmitRequirementDryRun, *http.Response, error)
+}
+
+// List lists the submit requirements that are defined in the project.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#list-submit-requirements
+func (s *SubmitRequirementService) List(ctx context.Context, opt *SubmitRequirementOptions) (*[]SubmitRequirementInfo, *http.Response, error) {
+	u := fmt.Sprintf("projects/%s/submit_requirements/", url.QueryEscape(s.project.Base))
+
+	v := &[]SubmitRequirementInfo{}
+	resp, err := s.gerrit.Requester.Call(ctx, "GET", u, opt, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// Get retrieves a submit requirement that is defined in the project.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-submit-requirement
+func (s *SubmitRequirementService) Get(ctx context.Context, name string) (*SubmitRequirementInfo, *http.Response, error) {
+	v := new(SubmitRequirementInfo)
+
+	resp, err := s.gerrit.Requester.Call(ctx, "GET", s.requirementURL(name), nil, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// Create creates a new submit requirement in the project. SubmittabilityExpression is mandatory.
+// It fails with 412 Precondition Failed when the submit requirement already exists, rather than updating it.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#create-submit-requirement
+func (s *SubmitRequirementService) Create(ctx context.Context, name string, input *SubmitRequirementInput) (*SubmitRequirementInfo, *http.Response, error) {
+	req, err := s.gerrit.Requester.NewRequest(ctx, "PUT", s.requirementURL(name), input)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The same PUT updates an existing submit requirement, unless it must not exist yet.
+	req.Header.Set("If-None-Match", "*")
+
+	v := new(SubmitRequirementInfo)
+	resp, err := s.gerrit.Requester.Do(req, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// Update replaces the definition of a submit requirement that is defined in the project.
+// It fails with 404 Not Found when the submit requirement does not exist, rather than creating it.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#update-submit-requirement
+func (s *SubmitRequirementService) Update(ctx context.Context, name string, input *SubmitRequirementInput) (*SubmitRequirementInfo, *http.Response, error) {
+	if _, resp, err := s.Get(ctx, name); err != nil {
+		return nil, resp, err
+	}
+
+	v := new(SubmitRequirementInfo)
+	resp, err := s.gerrit.Requester.Call(ctx, "PUT", s.requirementURL(name), input, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// Delete deletes a submit requirement that is defined in the project.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#delete-submit-requirement
+func (s *SubmitRequirementService) Delete(ctx context.Context, name string) (bool, *http.Response, error) {
+	resp, err := s.gerrit.Requester.Call(ctx, "DELETE", s.requirementURL(name), nil, nil)
+	if err != nil {
+		return false, resp, err
+	}
+	return true, resp, nil
+}
+
+// DryRun checks a submit requirement against a sample of open changes of the project with Change.CheckSubmitRequirements,
+// without saving it. A change that cannot be checked does not prevent checking the others.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#check-submit-requirements
+func (s *SubmitRequirementService) DryRun(ctx context.Context, input *SubmitRequirementInput, opt *SubmitRequirementDryRunOptions) (*SubmitRequirementDryRun, *http.Response, error) {
+	o := SubmitRequirementDryRunOptions{}
+	if opt != nil {
+		o = *opt
+	}
+	if o.Limit <= 0 {
+		o.Limit = 10
+	}
+
+	q := fmt.Sprintf("project:%s is:open", strconv.Quote(s.project.Base))
+	if o.Query != "" {
+		q += " (" + o.Query + ")"
+	}
+	query := &QueryChangeOptions{}
+	query.Query = []string{q}
+	query.Limit = o.Limit
+
+	changes, resp, err := s.gerrit.Changes.Query(ctx, query)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	dryRun := &SubmitRequirementDryRun{Requirement: *input}
+	for _, change := range *changes {
+		check := SubmitRequirementCheck{Change: change}
+		ref := ChangeRef{Project: change.Project, Number: change.Number}
+		check.Result, _, check.Err = NewChange(s.gerrit, ref.String()).CheckSubmitRequirements(ctx, input)
+		dryRun.Checks = append(dryRun.Checks, check)
+	}
+	return dryRun, resp, nil
+}
+
+func (s *SubmitRequirementService) requirementURL(name string) string {
+	return fmt.Sprintf("projects/%s/submit_requirements/%s", url.QueryEscape(s.project.Base), url.QueryEscape(name))
+}
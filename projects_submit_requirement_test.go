@@ -0,0 +1,115 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSubmitRequirementService(t *testing.T) {
+	requirement := SubmitRequirementInfo{Name: "Code-Review", SubmittabilityExpression: "label:Code-Review=MAX"}
+	var requests []string
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		request := r.Method + " " + r.URL.EscapedPath()
+		requests = append(requests, request)
+		switch request {
+		case "GET /projects/parent%2Fchild/submit_requirements/":
+			if r.URL.RawQuery != "inherited=true" {
+				t.Errorf("query = %q, want inherited=true", r.URL.RawQuery)
+			}
+			writeJSON(w, []SubmitRequirementInfo{requirement})
+		case "GET /projects/parent%2Fchild/submit_requirements/Code-Review":
+			writeJSON(w, requirement)
+		case "GET /projects/parent%2Fchild/submit_requirements/Missing":
+			http.Error(w, "Not found: Missing", http.StatusNotFound)
+		case "PUT /projects/parent%2Fchild/submit_requirements/Code-Review":
+			if r.Header.Get("If-None-Match") != "" {
+				t.Errorf("If-None-Match = %q, want none for updates", r.Header.Get("If-None-Match"))
+			}
+			writeJSON(w, requirement)
+		case "PUT /projects/parent%2Fchild/submit_requirements/Verified":
+			if r.Header.Get("If-None-Match") != "*" {
+				t.Errorf("If-None-Match = %q, want * for creations", r.Header.Get("If-None-Match"))
+			}
+			var in SubmitRequirementInput
+			readJSON(t, r, &in)
+			w.WriteHeader(http.StatusCreated)
+			writeJSON(w, SubmitRequirementInfo{Name: in.Name, SubmittabilityExpression: in.SubmittabilityExpression})
+		case "DELETE /projects/parent%2Fchild/submit_requirements/Code-Review":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s", request)
+		}
+	})
+	requirements := NewProject(client, "parent/child").SubmitRequirements
+	ctx := context.Background()
+
+	list, _, err := requirements.List(ctx, &SubmitRequirementOptions{Inherited: true})
+	if err != nil || !reflect.DeepEqual(*list, []SubmitRequirementInfo{requirement}) {
+		t.Errorf("List() = %+v, %v, want Code-Review", list, err)
+	}
+	created, _, err := requirements.Create(ctx, "Verified", &SubmitRequirementInput{Name: "Verified", SubmittabilityExpression: "label:Verified=MAX"})
+	if err != nil || created.Name != "Verified" {
+		t.Errorf("Create() = %+v, %v, want Verified", created, err)
+	}
+	if _, _, err := requirements.Update(ctx, "Code-Review", &SubmitRequirementInput{Name: "Code-Review", SubmittabilityExpression: "label:Code-Review=+2"}); err != nil {
+		t.Errorf("Update() error = %v", err)
+	}
+	if _, resp, err := requirements.Update(ctx, "Missing", &SubmitRequirementInput{Name: "Missing"}); err == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Update() of a missing requirement error = %v, want 404 Not Found", err)
+	}
+	if ok, _, err := requirements.Delete(ctx, "Code-Review"); !ok || err != nil {
+		t.Errorf("Delete() = %v, %v, want true", ok, err)
+	}
+	for _, request := range requests {
+		if request == "PUT /projects/parent%2Fchild/submit_requirements/Missing" {
+			t.Error("Update() of a missing requirement sent the PUT creating it")
+		}
+	}
+}
+
+func TestSubmitRequirementDryRun(t *testing.T) {
+	input := &SubmitRequirementInput{Name: "Verified", SubmittabilityExpression: "label:Verified=MAX"}
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "GET /changes/":
+			if q, n := r.URL.Query().Get("q"), r.URL.Query().Get("n"); q != `project:"parent/child" is:open (branch:main)` || n != "3" {
+				t.Errorf("query = %q limited to %s, want the open changes of the project on main, limited to 3", q, n)
+			}
+			writeJSON(w, []ChangeInfo{{Project: "parent/child", Number: 1}, {Project: "parent/child", Number: 2}, {Project: "parent/child", Number: 3}})
+		case "POST /changes/parent%2Fchild~1/check.submit_requirement":
+			var in SubmitRequirementInput
+			readJSON(t, r, &in)
+			if !reflect.DeepEqual(&in, input) {
+				t.Errorf("checked requirement = %+v, want %+v", in, input)
+			}
+			writeJSON(w, SubmitRequirementResultInfo{Name: "Verified", Status: "SATISFIED"})
+		case "POST /changes/parent%2Fchild~2/check.submit_requirement":
+			writeJSON(w, SubmitRequirementResultInfo{Name: "Verified", Status: "ERROR"})
+		case "POST /changes/parent%2Fchild~3/check.submit_requirement":
+			http.Error(w, "change is not visible", http.StatusNotFound)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	})
+
+	dryRun, _, err := NewProject(client, "parent/child").SubmitRequirements.DryRun(context.Background(), input,
+		&SubmitRequirementDryRunOptions{Query: "branch:main", Limit: 3})
+	if err != nil {
+		t.Fatalf("DryRun() error = %v", err)
+	}
+	if len(dryRun.Checks) != 3 || dryRun.Checks[2].Err == nil {
+		t.Fatalf("DryRun() checks = %+v, want 3 with the last failing", dryRun.Checks)
+	}
+	if want := map[string]int{"SATISFIED": 1, "ERROR": 2}; !reflect.DeepEqual(dryRun.Counts(), want) {
+		t.Errorf("Counts() = %v, want %v", dryRun.Counts(), want)
+	}
+	var failed []int
+	for _, check := range dryRun.Errors() {
+		failed = append(failed, check.Change.Number)
+	}
+	if want := []int{2, 3}; !reflect.DeepEqual(failed, want) {
+		t.Errorf("Errors() = changes %v, want %v", failed, want)
+	}
+}
@@ -0,0 +1,745 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ProjectState is the desired state of a project, e.g. decoded from a YAML or JSON file kept in Git.
+// It uses the JSON field names of the REST API, so it can be decoded with encoding/json
+// or any YAML library that honours json tags.
+//
+// Parts of the state that are left empty are not managed: the live project is kept as it is.
+type ProjectState struct {
+	Description *string `json:"description,omitempty"`
+	Parent      string  `json:"parent,omitempty"`
+
+	// HEAD is the branch HEAD points to, e.g. "main" or "refs/heads/main".
+	HEAD string `json:"head,omitempty"`
+
+	// Config holds the project.config options to set. Description is ignored in favour of ProjectState.Description.
+	Config *ConfigInput `json:"config,omitempty"`
+
+	// Access maps ref patterns, e.g. "refs/heads/*", to the local access section of the project.
+	// A section that differs from the live one is replaced as a whole.
+	Access map[string]AccessSectionInfo `json:"access,omitempty"`
+
+	// Branches maps branch names to the revision they are created from when missing. Existing branches are not moved.
+	Branches map[string]string `json:"branches,omitempty"`
+
+	// Labels maps label names to their definitions.
+	Labels map[string]LabelDefinitionInput `json:"labels,omitempty"`
+
+	// SubmitRequirements maps submit requirement names to their definitions.
+	SubmitRequirements map[string]SubmitRequirementInput `json:"submit_requirements,omitempty"`
+
+	// Prune deletes the access sections, branches, labels and submit requirements of the project that are not
+	// part of the desired state. Only managed, i.e. non-empty, parts of the state are pruned.
+	Prune bool `json:"prune,omitempty"`
+
+	// CommitMessage is the commit message of the updates of refs/meta/config.
+	CommitMessage string `json:"commit_message,omitempty"`
+}
+
+// PlanAction is what a ProjectPlanStep does to a resource.
+type PlanAction string
+
+const (
+	PlanCreate PlanAction = "create"
+	PlanUpdate PlanAction = "update"
+	PlanDelete PlanAction = "delete"
+)
+
+// ProjectPlanStep is a single update of a ProjectPlan.
+type ProjectPlanStep struct {
+	Action PlanAction
+
+	// Resource is the kind of resource updated: "parent", "description", "config", "access", "label",
+	// "submit-requirement", "branch" or "HEAD".
+	Resource string
+
+	// Name identifies the resource, e.g. the ref pattern of an access section or the name of a branch.
+	Name string
+
+	// Details describe the update in a human-readable form, e.g. "use_content_merge: INHERIT -> TRUE".
+	Details []string
+
+	apply func(ctx context.Context) (*http.Response, error)
+}
+
+// String formats the step as a line of a plan, e.g. "~ config" or "+ branch stable-1.0".
+func (s ProjectPlanStep) String() string {
+	symbol := map[PlanAction]string{PlanCreate: "+", PlanUpdate: "~", PlanDelete: "-"}[s.Action]
+	line := fmt.Sprintf("%s %s", symbol, s.Resource)
+	if s.Name != "" {
+		line += " " + s.Name
+	}
+	return line
+}
+
+// ProjectPlan is the list of updates that bring a project to its desired state, in the order they are applied:
+// parent, description, config, access, labels, submit requirements, new branches, HEAD and deleted branches.
+type ProjectPlan struct {
+	Project string
+	Steps   []ProjectPlanStep
+}
+
+// Empty reports whether the project is already in its desired state.
+func (p *ProjectPlan) Empty() bool {
+	return len(p.Steps) == 0
+}
+
+// String formats the plan for humans, one line per step followed by its details.
+func (p *ProjectPlan) String() string {
+	if p.Empty() {
+		return fmt.Sprintf("project %s is up to date", p.Project)
+	}
+	lines := []string{fmt.Sprintf("project %s: %d change(s)", p.Project, len(p.Steps))}
+	for _, step := range p.Steps {
+		lines = append(lines, "  "+step.String())
+		for _, detail := range step.Details {
+			lines = append(lines, "      "+detail)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ProjectStepError is a step of a plan that failed to apply.
+type ProjectStepError struct {
+	Step     ProjectPlanStep
+	Response *http.Response
+	Err      error
+}
+
+func (e *ProjectStepError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Step.String(), e.Err)
+}
+
+func (e *ProjectStepError) Unwrap() error {
+	return e.Err
+}
+
+// ProjectApplyResult reports which steps of a plan were applied and which failed.
+type ProjectApplyResult struct {
+	Applied []ProjectPlanStep
+	Failed  []*ProjectStepError
+}
+
+// Err returns an error joining the failures, or nil when every step was applied.
+func (r *ProjectApplyResult) Err() error {
+	errs := make([]error, 0, len(r.Failed))
+	for _, failure := range r.Failed {
+		errs = append(errs, failure)
+	}
+	return errors.Join(errs...)
+}
+
+// Plan compares the desired state of the project with its live state,
+// read with GetParent, GetConfig, GetAccess, GetHEAD and Branches.List, and returns the updates to apply.
+func (p *Project) Plan(ctx context.Context, desired *ProjectState) (*ProjectPlan, *http.Response, error) {
+	plan := &ProjectPlan{Project: p.Base}
+	var (
+		steps []ProjectPlanStep
+		resp  *http.Response
+	)
+
+	if desired.Parent != "" {
+		parent, r, err := p.GetParent(ctx)
+		resp = r
+		if err != nil {
+			return nil, resp, err
+		}
+		if parent != desired.Parent {
+			input := &ProjectParentInput{Parent: desired.Parent, CommitMessage: desired.CommitMessage}
+			steps = append(steps, ProjectPlanStep{
+				Action:   PlanUpdate,
+				Resource: "parent",
+				Details:  []string{fmt.Sprintf("%s -> %s", parent, desired.Parent)},
+				apply: func(ctx context.Context) (*http.Response, error) {
+					_, resp, err := p.SetParent(ctx, input)
+					return resp, err
+				},
+			})
+		}
+	}
+
+	if desired.Description != nil || desired.Config != nil {
+		config, r, err := p.GetConfig(ctx)
+		resp = r
+		if err != nil {
+			return nil, resp, err
+		}
+		if desired.Description != nil && *desired.Description != config.Description {
+			input := &ProjectDescriptionInput{Description: *desired.Description, CommitMessage: desired.CommitMessage}
+			steps = append(steps, ProjectPlanStep{
+				Action:   PlanUpdate,
+				Resource: "description",
+				Details:  []string{fmt.Sprintf("%q -> %q", config.Description, *desired.Description)},
+				apply: func(ctx context.Context) (*http.Response, error) {
+					_, resp, err := p.SetDescription(ctx, input)
+					return resp, err
+				},
+			})
+		}
+		if desired.Config != nil {
+			var plugins map[string]map[string]ConfigParameterInfo
+			if len(desired.Config.PluginConfigValues) > 0 {
+				if plugins, resp, err = p.getPluginConfig(ctx); err != nil {
+					return nil, resp, err
+				}
+			}
+			if input, details := configChanges(config, plugins, desired.Config); len(details) > 0 {
+				steps = append(steps, ProjectPlanStep{
+					Action:   PlanUpdate,
+					Resource: "config",
+					Details:  details,
+					apply: func(ctx context.Context) (*http.Response, error) {
+						_, resp, err := p.SetConfig(ctx, input)
+						return resp, err
+					},
+				})
+			}
+		}
+	}
+
+	if len(desired.Access) > 0 {
+		access, r, err := p.GetAccess(ctx)
+		resp = r
+		if err != nil {
+			return nil, resp, err
+		}
+		steps = append(steps, p.planAccess(access.Local, desired)...)
+	}
+
+	if len(desired.Labels) > 0 {
+		labels, r, err := p.Labels.List(ctx, nil)
+		resp = r
+		if err != nil {
+			return nil, resp, err
+		}
+		steps = append(steps, p.planLabels(*labels, desired)...)
+	}
+
+	if len(desired.SubmitRequirements) > 0 {
+		requirements, r, err := p.SubmitRequirements.List(ctx, nil)
+		resp = r
+		if err != nil {
+			return nil, resp, err
+		}
+		steps = append(steps, p.planSubmitRequirements(*requirements, desired)...)
+	}
+
+	var (
+		head       string
+		branchDels []ProjectPlanStep
+	)
+	if desired.HEAD != "" || (desired.Prune && len(desired.Branches) > 0) {
+		h, r, err := p.GetHEAD(ctx)
+		resp = r
+		if err != nil {
+			return nil, resp, err
+		}
+		head = h
+	}
+	if len(desired.Branches) > 0 {
+		branches, r, err := p.Branches.List(ctx, nil)
+		resp = r
+		if err != nil {
+			return nil, resp, err
+		}
+		var creates []ProjectPlanStep
+		creates, branchDels = p.planBranches(*branches, head, desired)
+		steps = append(steps, creates...)
+	}
+
+	if desired.HEAD != "" {
+		ref := desired.HEAD
+		if !strings.HasPrefix(ref, "refs/") {
+			ref = "refs/heads/" + ref
+		}
+		if ref != head {
+			input := &HeadInput{Ref: ref}
+			steps = append(steps, ProjectPlanStep{
+				Action:   PlanUpdate,
+				Resource: "HEAD",
+				Details:  []string{fmt.Sprintf("%s -> %s", head, ref)},
+				apply: func(ctx context.Context) (*http.Response, error) {
+					_, resp, err := p.SetHEAD(ctx, input)
+					return resp, err
+				},
+			})
+		}
+	}
+	steps = append(steps, branchDels...)
+
+	plan.Steps = steps
+	return plan, resp, nil
+}
+
+// Apply applies the steps of the plan in order.
+// A failing step does not prevent the following ones from being applied; all failures are reported.
+func (p *ProjectPlan) Apply(ctx context.Context) *ProjectApplyResult {
+	result := &ProjectApplyResult{}
+	for _, step := range p.Steps {
+		resp, err := step.apply(ctx)
+		if err != nil {
+			result.Failed = append(result.Failed, &ProjectStepError{Step: step, Response: resp, Err: err})
+			continue
+		}
+		result.Applied = append(result.Applied, step)
+	}
+	return result
+}
+
+// Sync plans and applies the desired state of the project.
+// The error is the one of planning, or the joined failures of applying the plan.
+func (p *Project) Sync(ctx context.Context, desired *ProjectState) (*ProjectPlan, *ProjectApplyResult, error) {
+	plan, _, err := p.Plan(ctx, desired)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := plan.Apply(ctx)
+	return plan, result, result.Err()
+}
+
+func (p *Project) planAccess(live map[string]AccessSectionInfo, desired *ProjectState) []ProjectPlanStep {
+	var steps []ProjectPlanStep
+	for _, ref := range sortedMapKeys(desired.Access) {
+		section := desired.Access[ref]
+		current, exists := live[ref]
+		if exists && sameJSON(normalizeAccessSection(current), normalizeAccessSection(section)) {
+			continue
+		}
+
+		input := &ProjectAccessInput{
+			Add:     map[string]AccessSectionInfo{ref: section},
+			Message: desired.CommitMessage,
+		}
+		step := ProjectPlanStep{Action: PlanCreate, Resource: "access", Name: ref}
+		if exists {
+			step.Action = PlanUpdate
+			input.Remove = map[string]AccessSectionInfo{ref: {}}
+		}
+		step.Details = permissionChanges(current.Permissions, section.Permissions)
+		step.apply = func(ctx context.Context) (*http.Response, error) {
+			_, resp, err := p.SetAccess(ctx, input)
+			return resp, err
+		}
+		steps = append(steps, step)
+	}
+
+	if desired.Prune {
+		for _, ref := range sortedMapKeys(live) {
+			if _, ok := desired.Access[ref]; ok {
+				continue
+			}
+			input := &ProjectAccessInput{
+				Remove:  map[string]AccessSectionInfo{ref: {}},
+				Message: desired.CommitMessage,
+			}
+			steps = append(steps, ProjectPlanStep{
+				Action:   PlanDelete,
+				Resource: "access",
+				Name:     ref,
+				apply: func(ctx context.Context) (*http.Response, error) {
+					_, resp, err := p.SetAccess(ctx, input)
+					return resp, err
+				},
+			})
+		}
+	}
+	return steps
+}
+
+func (p *Project) planLabels(live []LabelDefinitionInfo, desired *ProjectState) []ProjectPlanStep {
+	current := make(map[string]LabelDefinitionInfo, len(live))
+	for _, label := range live {
+		current[label.Name] = label
+	}
+
+	var steps []ProjectPlanStep
+	for _, name := range sortedMapKeys(desired.Labels) {
+		name := name
+		input := desired.Labels[name]
+		if input.CommitMessage == "" {
+			input.CommitMessage = desired.CommitMessage
+		}
+
+		step := ProjectPlanStep{Action: PlanCreate, Resource: "label", Name: name}
+		if label, ok := current[name]; ok {
+			if step.Details = labelChanges(label, input); len(step.Details) == 0 {
+				continue
+			}
+			step.Action = PlanUpdate
+		}
+		if step.Action == PlanCreate {
+			step.apply = func(ctx context.Context) (*http.Response, error) {
+				_, resp, err := p.Labels.Create(ctx, name, &input)
+				return resp, err
+			}
+		} else {
+			step.apply = func(ctx context.Context) (*http.Response, error) {
+				_, resp, err := p.Labels.Update(ctx, name, &input)
+				return resp, err
+			}
+		}
+		steps = append(steps, step)
+	}
+
+	if desired.Prune {
+		for _, label := range live {
+			name := label.Name
+			if _, ok := desired.Labels[name]; ok {
+				continue
+			}
+			input := &DeleteLabelInput{CommitMessage: desired.CommitMessage}
+			steps = append(steps, ProjectPlanStep{
+				Action:   PlanDelete,
+				Resource: "label",
+				Name:     name,
+				apply: func(ctx context.Context) (*http.Response, error) {
+					_, resp, err := p.Labels.Delete(ctx, name, input)
+					return resp, err
+				},
+			})
+		}
+	}
+	return steps
+}
+
+func (p *Project) planSubmitRequirements(live []SubmitRequirementInfo, desired *ProjectState) []ProjectPlanStep {
+	current := make(map[string]SubmitRequirementInfo, len(live))
+	for _, requirement := range live {
+		current[requirement.Name] = requirement
+	}
+
+	var steps []ProjectPlanStep
+	for _, name := range sortedMapKeys(desired.SubmitRequirements) {
+		name := name
+		input := desired.SubmitRequirements[name]
+		input.Name = name
+
+		step := ProjectPlanStep{Action: PlanCreate, Resource: "submit-requirement", Name: name}
+		if requirement, ok := current[name]; ok {
+			if step.Details = submitRequirementChanges(requirement, input); len(step.Details) == 0 {
+				continue
+			}
+			step.Action = PlanUpdate
+		}
+		if step.Action == PlanCreate {
+			step.apply = func(ctx context.Context) (*http.Response, error) {
+				_, resp, err := p.SubmitRequirements.Create(ctx, name, &input)
+				return resp, err
+			}
+		} else {
+			step.apply = func(ctx context.Context) (*http.Response, error) {
+				_, resp, err := p.SubmitRequirements.Update(ctx, name, &input)
+				return resp, err
+			}
+		}
+		steps = append(steps, step)
+	}
+
+	if desired.Prune {
+		for _, requirement := range live {
+			name := requirement.Name
+			if _, ok := desired.SubmitRequirements[name]; ok {
+				continue
+			}
+			steps = append(steps, ProjectPlanStep{
+				Action:   PlanDelete,
+				Resource: "submit-requirement",
+				Name:     name,
+				apply: func(ctx context.Context) (*http.Response, error) {
+					_, resp, err := p.SubmitRequirements.Delete(ctx, name)
+					return resp, err
+				},
+			})
+		}
+	}
+	return steps
+}
+
+// planBranches returns the steps creating the missing branches and, when pruning, deleting the extra ones.
+// The branch HEAD points to is never deleted.
+func (p *Project) planBranches(live []BranchInfo, head string, desired *ProjectState) ([]ProjectPlanStep, []ProjectPlanStep) {
+	wanted := make(map[string]string, len(desired.Branches))
+	for name, revision := range desired.Branches {
+		wanted[strings.TrimPrefix(name, "refs/heads/")] = revision
+	}
+	existing := make(map[string]bool, len(live))
+	for _, branch := range live {
+		if strings.HasPrefix(branch.Ref, "refs/heads/") {
+			existing[strings.TrimPrefix(branch.Ref, "refs/heads/")] = true
+		}
+	}
+
+	var creates, deletes []ProjectPlanStep
+	for _, name := range sortedMapKeys(wanted) {
+		if existing[name] {
+			continue
+		}
+		name := name
+		input := &BranchInput{Revision: wanted[name]}
+		step := ProjectPlanStep{
+			Action:   PlanCreate,
+			Resource: "branch",
+			Name:     name,
+			apply: func(ctx context.Context) (*http.Response, error) {
+				_, resp, err := p.Branches.Create(ctx, name, input)
+				return resp, err
+			},
+		}
+		if input.Revision != "" {
+			step.Details = []string{"from " + input.Revision}
+		}
+		creates = append(creates, step)
+	}
+
+	if desired.Prune {
+		keep := strings.TrimPrefix(head, "refs/heads/")
+		if desired.HEAD != "" {
+			keep = strings.TrimPrefix(desired.HEAD, "refs/heads/")
+		}
+		for _, name := range sortedMapKeys(existing) {
+			if _, ok := wanted[name]; ok || name == keep {
+				continue
+			}
+			name := name
+			deletes = append(deletes, ProjectPlanStep{
+				Action:   PlanDelete,
+				Resource: "branch",
+				Name:     name,
+				apply: func(ctx context.Context) (*http.Response, error) {
+					_, resp, err := p.Branches.Delete(ctx, name)
+					return resp, err
+				},
+			})
+		}
+	}
+	return creates, deletes
+}
+
+// configChanges returns a ConfigInput holding only the options that differ from the live config, and a description of them.
+func configChanges(live *ConfigInfo, plugins map[string]map[string]ConfigParameterInfo, desired *ConfigInput) (*ConfigInput, []string) {
+	input := &ConfigInput{}
+	var details []string
+
+	booleans := []struct {
+		name    string
+		live    InheritedBooleanInfo
+		desired string
+		set     *string
+	}{
+		{"use_contributor_agreements", live.UseContributorAgreements, desired.UseContributorAgreements, &input.UseContributorAgreements},
+		{"use_content_merge", live.UseContentMerge, desired.UseContentMerge, &input.UseContentMerge},
+		{"use_signed_off_by", live.UseSignedOffBy, desired.UseSignedOffBy, &input.UseSignedOffBy},
+		{"create_new_change_for_all_not_in_target", live.CreateNewChangeForAllNotInTarget, desired.CreateNewChangeForAllNotInTarget, &input.CreateNewChangeForAllNotInTarget},
+		{"enable_signed_push", live.EnableSignedPush, desired.EnableSignedPush, &input.EnableSignedPush},
+		{"require_signed_push", live.RequireSignedPush, desired.RequireSignedPush, &input.RequireSignedPush},
+		{"reject_implicit_merges", live.RejectImplicitMerges, desired.RejectImplicitMerges, &input.RejectImplicitMerges},
+		{"require_change_id", live.RequireChangeID, desired.RequireChangeID, &input.RequireChangeID},
+	}
+	for _, b := range booleans {
+		configured := b.live.ConfiguredValue
+		if configured == "" {
+			configured = "INHERIT"
+		}
+		if b.desired != "" && !strings.EqualFold(b.desired, configured) {
+			*b.set = strings.ToUpper(b.desired)
+			details = append(details, fmt.Sprintf("%s: %s -> %s", b.name, configured, *b.set))
+		}
+	}
+
+	if desired.MaxObjectSizeLimit != nil {
+		limit := fmt.Sprint(desired.MaxObjectSizeLimit)
+		if limit != live.MaxObjectSizeLimit.ConfiguredValue {
+			input.MaxObjectSizeLimit = desired.MaxObjectSizeLimit
+			details = append(details, fmt.Sprintf("max_object_size_limit: %q -> %q", live.MaxObjectSizeLimit.ConfiguredValue, limit))
+		}
+	}
+	// SubmitType is the effective submit type, which is never INHERIT: compare with the configured one instead.
+	// Servers older than 2.15 have no default_submit_type, and only the effective submit type can be compared.
+	submitType := live.DefaultSubmitType.ConfiguredValue
+	if submitType == "" {
+		submitType = live.SubmitType
+	}
+	if desired.SubmitType != "" && !strings.EqualFold(desired.SubmitType, submitType) {
+		input.SubmitType = desired.SubmitType
+		details = append(details, fmt.Sprintf("submit_type: %s -> %s", submitType, desired.SubmitType))
+	}
+	if desired.State != "" && !strings.EqualFold(desired.State, live.State) {
+		input.State = desired.State
+		details = append(details, fmt.Sprintf("state: %s -> %s", live.State, desired.State))
+	}
+
+	for _, plugin := range sortedMapKeys(desired.PluginConfigValues) {
+		for _, param := range sortedMapKeys(desired.PluginConfigValues[plugin]) {
+			value := desired.PluginConfigValues[plugin][param]
+			current := plugins[plugin][param].Value
+			if value == current {
+				continue
+			}
+			if input.PluginConfigValues == nil {
+				input.PluginConfigValues = make(map[string]map[string]string)
+			}
+			if input.PluginConfigValues[plugin] == nil {
+				input.PluginConfigValues[plugin] = make(map[string]string)
+			}
+			input.PluginConfigValues[plugin][param] = value
+			details = append(details, fmt.Sprintf("plugin %s %s: %q -> %q", plugin, param, current, value))
+		}
+	}
+
+	return input, details
+}
+
+// permissionChanges describes the permissions that are added, updated or removed between two access sections.
+func permissionChanges(live, desired map[string]PermissionInfo) []string {
+	var details []string
+	for _, name := range sortedMapKeys(desired) {
+		current, ok := live[name]
+		switch {
+		case !ok:
+			details = append(details, "+ "+name)
+		case !sameJSON(normalizePermission(current), normalizePermission(desired[name])):
+			details = append(details, "~ "+name)
+		}
+	}
+	for _, name := range sortedMapKeys(live) {
+		if _, ok := desired[name]; !ok {
+			details = append(details, "- "+name)
+		}
+	}
+	return details
+}
+
+// labelChanges describes how the fields set in a LabelDefinitionInput differ from the live label.
+func labelChanges(live LabelDefinitionInfo, desired LabelDefinitionInput) []string {
+	var details []string
+	diff := func(field string, old, new interface{}) {
+		if !reflect.DeepEqual(old, new) {
+			details = append(details, fmt.Sprintf("%s: %v -> %v", field, old, new))
+		}
+	}
+
+	if desired.Description != "" {
+		diff("description", live.Description, desired.Description)
+	}
+	if desired.Function != "" {
+		diff("function", live.Function, desired.Function)
+	}
+	if desired.Values != nil {
+		diff("values", normalizeLabelValues(live.Values), normalizeLabelValues(desired.Values))
+	}
+	if desired.DefaultValue != nil {
+		diff("default_value", live.DefaultValue, *desired.DefaultValue)
+	}
+	if desired.Branches != nil {
+		diff("branches", live.Branches, desired.Branches)
+	}
+	if desired.CanOverride != nil {
+		diff("can_override", live.CanOverride, *desired.CanOverride)
+	}
+	if desired.CopyCondition != "" {
+		diff("copy_condition", live.CopyCondition, desired.CopyCondition)
+	}
+	if desired.UnsetCopyCondition != nil && *desired.UnsetCopyCondition && live.CopyCondition != "" {
+		diff("copy_condition", live.CopyCondition, "")
+	}
+	if desired.AllowPostSubmit != nil {
+		diff("allow_post_submit", live.AllowPostSubmit, *desired.AllowPostSubmit)
+	}
+	if desired.IgnoreSelfApproval != nil {
+		diff("ignore_self_approval", live.IgnoreSelfApproval, *desired.IgnoreSelfApproval)
+	}
+	return details
+}
+
+// submitRequirementChanges describes how a SubmitRequirementInput differs from the live submit requirement.
+func submitRequirementChanges(live SubmitRequirementInfo, desired SubmitRequirementInput) []string {
+	var details []string
+	diff := func(field string, old, new interface{}) {
+		if old != new {
+			details = append(details, fmt.Sprintf("%s: %v -> %v", field, old, new))
+		}
+	}
+	diff("description", live.Description, desired.Description)
+	diff("applicability_expression", live.ApplicabilityExpression, desired.ApplicabilityExpression)
+	diff("submittability_expression", live.SubmittabilityExpression, desired.SubmittabilityExpression)
+	diff("override_expression", live.OverrideExpression, desired.OverrideExpression)
+	diff("allow_override_in_child_projects", live.AllowOverrideInChildProjects, desired.AllowOverrideInChildProjects)
+	return details
+}
+
+// normalizeLabelValues keys label values by their numeric value, as Gerrit formats " 0" and "0" alike.
+func normalizeLabelValues(values map[string]string) map[int]string {
+	normalized := make(map[int]string, len(values))
+	for key, description := range values {
+		if v, err := ParseLabelValue(key); err == nil {
+			normalized[v] = strings.TrimSpace(description)
+		}
+	}
+	return normalized
+}
+
+// normalizeAccessSection drops the fields Gerrit derives on its own, so that live and desired sections can be compared.
+func normalizeAccessSection(section AccessSectionInfo) AccessSectionInfo {
+	normalized := AccessSectionInfo{Permissions: make(map[string]PermissionInfo, len(section.Permissions))}
+	for name, permission := range section.Permissions {
+		normalized.Permissions[name] = normalizePermission(permission)
+	}
+	return normalized
+}
+
+func normalizePermission(permission PermissionInfo) PermissionInfo {
+	permission.Label = ""
+	if len(permission.Rules) == 0 {
+		permission.Rules = nil
+	}
+	return permission
+}
+
+// sameJSON reports whether two values have the same JSON encoding.
+func sameJSON(a, b interface{}) bool {
+	x, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	y, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(x) == string(y)
+}
+
+// sortedMapKeys returns the keys of a map with string keys in sorted order.
+func sortedMapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getPluginConfig reads the plugin_config of the project config, which maps plugin names to their parameters.
+func (p *Project) getPluginConfig(ctx context.Context) (map[string]map[string]ConfigParameterInfo, *http.Response, error) {
+	var v struct {
+		PluginConfig map[string]map[string]ConfigParameterInfo `json:"plugin_config"`
+	}
+	u := fmt.Sprintf("projects/%s/config", url.QueryEscape(p.Base))
+
+	resp, err := p.gerrit.Requester.Call(ctx, "GET", u, nil, &v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v.PluginConfig, resp, nil
+}
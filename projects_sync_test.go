@@ -0,0 +1,472 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigChanges(t *testing.T) {
+	inherited := InheritedBooleanInfo{Value: true, ConfiguredValue: "INHERIT", InheritedValue: true}
+	live := &ConfigInfo{
+		UseContentMerge:    inherited,
+		RequireChangeID:    InheritedBooleanInfo{Value: true, ConfiguredValue: "TRUE"},
+		MaxObjectSizeLimit: MaxObjectSizeLimitInfo{Value: "10m", ConfiguredValue: "10m"},
+		DefaultSubmitType:  SubmitTypeInfo{Value: "MERGE_IF_NECESSARY", ConfiguredValue: "INHERIT", InheritedValue: "MERGE_IF_NECESSARY"},
+		SubmitType:         "MERGE_IF_NECESSARY",
+		State:              "ACTIVE",
+	}
+	plugins := map[string]map[string]ConfigParameterInfo{"reviewers": {"enabled": {Value: "true"}}}
+
+	tests := []struct {
+		name    string
+		live    *ConfigInfo
+		desired ConfigInput
+		want    ConfigInput
+		details []string
+	}{
+		{
+			name:    "same values",
+			live:    live,
+			desired: ConfigInput{UseContentMerge: "inherit", RequireChangeID: "TRUE", MaxObjectSizeLimit: "10m", SubmitType: "INHERIT", State: "active"},
+		},
+		{
+			name:    "unset boolean is inherited",
+			live:    &ConfigInfo{},
+			desired: ConfigInput{UseSignedOffBy: "INHERIT"},
+		},
+		{
+			name:    "changed values",
+			live:    live,
+			desired: ConfigInput{UseContentMerge: "true", RequireChangeID: "INHERIT", MaxObjectSizeLimit: "20m", SubmitType: "REBASE_ALWAYS", State: "READ_ONLY"},
+			want:    ConfigInput{UseContentMerge: "TRUE", RequireChangeID: "INHERIT", MaxObjectSizeLimit: "20m", SubmitType: "REBASE_ALWAYS", State: "READ_ONLY"},
+			details: []string{
+				"use_content_merge: INHERIT -> TRUE",
+				"require_change_id: TRUE -> INHERIT",
+				`max_object_size_limit: "10m" -> "20m"`,
+				"submit_type: INHERIT -> REBASE_ALWAYS",
+				"state: ACTIVE -> READ_ONLY",
+			},
+		},
+		{
+			name:    "explicit submit type equal to the inherited one",
+			live:    live,
+			desired: ConfigInput{SubmitType: "MERGE_IF_NECESSARY"},
+			want:    ConfigInput{SubmitType: "MERGE_IF_NECESSARY"},
+			details: []string{"submit_type: INHERIT -> MERGE_IF_NECESSARY"},
+		},
+		{
+			name:    "server without default submit type",
+			live:    &ConfigInfo{SubmitType: "MERGE_IF_NECESSARY"},
+			desired: ConfigInput{SubmitType: "merge_if_necessary"},
+		},
+		{
+			name:    "plugin config",
+			live:    live,
+			desired: ConfigInput{PluginConfigValues: map[string]map[string]string{"reviewers": {"enabled": "true", "max": "3"}}},
+			want:    ConfigInput{PluginConfigValues: map[string]map[string]string{"reviewers": {"max": "3"}}},
+			details: []string{`plugin reviewers max: "" -> "3"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, details := configChanges(tt.live, plugins, &tt.desired)
+			if !reflect.DeepEqual(*input, tt.want) {
+				t.Errorf("configChanges() input = %+v, want %+v", *input, tt.want)
+			}
+			if !reflect.DeepEqual(details, tt.details) {
+				t.Errorf("configChanges() details = %q, want %q", details, tt.details)
+			}
+		})
+	}
+}
+
+func TestPermissionChanges(t *testing.T) {
+	rules := func(action PermissionAction) map[string]PermissionRuleInfo {
+		return map[string]PermissionRuleInfo{"group-uuid": {Action: action}}
+	}
+	live := map[string]PermissionInfo{
+		"read":              {Rules: rules(PermissionActionAllow)},
+		"push":              {Rules: rules(PermissionActionAllow)},
+		"label-Code-Review": {Label: "Code-Review", Rules: rules(PermissionActionAllow)},
+		"submit":            {Rules: rules(PermissionActionAllow)},
+	}
+	desired := map[string]PermissionInfo{
+		"read":              {Rules: rules(PermissionActionAllow)},
+		"push":              {Rules: rules(PermissionActionBlock)},
+		"label-Code-Review": {Rules: rules(PermissionActionAllow)},
+		"create":            {Rules: rules(PermissionActionAllow)},
+	}
+	want := []string{"+ create", "~ push", "- submit"}
+	if got := permissionChanges(live, desired); !reflect.DeepEqual(got, want) {
+		t.Errorf("permissionChanges() = %q, want %q", got, want)
+	}
+
+	// Gerrit derives the label of label permissions and omits empty rules.
+	if !sameJSON(normalizeAccessSection(AccessSectionInfo{Permissions: live}), normalizeAccessSection(AccessSectionInfo{Permissions: map[string]PermissionInfo{
+		"read":              {Rules: rules(PermissionActionAllow)},
+		"push":              {Rules: rules(PermissionActionAllow)},
+		"label-Code-Review": {Rules: rules(PermissionActionAllow)},
+		"submit":            {Rules: rules(PermissionActionAllow)},
+	}})) {
+		t.Error("normalizeAccessSection() keeps the derived label")
+	}
+	if !reflect.DeepEqual(normalizePermission(PermissionInfo{Rules: map[string]PermissionRuleInfo{}}), PermissionInfo{}) {
+		t.Error("normalizePermission() keeps empty rules")
+	}
+}
+
+func TestSameJSON(t *testing.T) {
+	tests := []struct {
+		a, b interface{}
+		want bool
+	}{
+		{map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2, "a": 1}, true},
+		{PermissionRuleInfo{Action: PermissionActionAllow}, &PermissionRuleInfo{Action: "ALLOW"}, true},
+		{PermissionRuleInfo{Action: PermissionActionAllow}, PermissionRuleInfo{Action: PermissionActionAllow, Force: true}, false},
+		{[]string{"a", "b"}, []string{"b", "a"}, false},
+		{func() {}, func() {}, false},
+	}
+	for _, tt := range tests {
+		if got := sameJSON(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameJSON(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSortedMapKeys(t *testing.T) {
+	if got, want := sortedMapKeys(map[string]int{"b": 1, "c": 2, "a": 3}), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sortedMapKeys() = %v, want %v", got, want)
+	}
+	if got := sortedMapKeys(map[string]bool(nil)); len(got) != 0 {
+		t.Errorf("sortedMapKeys(nil) = %v, want no keys", got)
+	}
+}
+
+// syncServer answers the reads of Project.Plan with a live project and records the updates of Apply,
+// failing the ones in fail.
+func syncServer(t *testing.T, fail map[string]bool, updates *[]string) *Gerrit {
+	return newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		request := r.Method + " " + strings.TrimPrefix(r.URL.EscapedPath(), "/projects/myProject/")
+		if r.Method != "GET" {
+			*updates = append(*updates, request)
+			if fail[request] {
+				http.Error(w, "not permitted", http.StatusForbidden)
+				return
+			}
+		}
+		switch request {
+		case "GET parent":
+			writeJSON(w, "All-Projects")
+		case "GET HEAD":
+			writeJSON(w, "refs/heads/master")
+		case "GET config":
+			writeJSON(w, ConfigInfo{
+				Description:       "Old",
+				DefaultSubmitType: SubmitTypeInfo{ConfiguredValue: "INHERIT"},
+				SubmitType:        "MERGE_IF_NECESSARY",
+			})
+		case "GET access":
+			writeJSON(w, ProjectAccessInfo{Local: map[string]AccessSectionInfo{
+				"refs/heads/*": {Permissions: map[string]PermissionInfo{"read": {Rules: map[string]PermissionRuleInfo{"g": {Action: PermissionActionAllow}}}}},
+				"refs/tags/*":  {Permissions: map[string]PermissionInfo{"create": {}}},
+			}})
+		case "GET branches/":
+			writeJSON(w, []BranchInfo{{Ref: "HEAD"}, {Ref: "refs/heads/master"}, {Ref: "refs/heads/old"}, {Ref: "refs/meta/config"}})
+		case "PUT branches/main", "PUT branches/stable":
+			writeJSON(w, BranchInfo{Ref: "refs/heads/" + strings.TrimPrefix(request, "PUT branches/")})
+		case "DELETE branches/old":
+			w.WriteHeader(http.StatusNoContent)
+		case "PUT config":
+			writeJSON(w, ConfigInfo{})
+		case "POST access":
+			writeJSON(w, ProjectAccessInfo{})
+		default:
+			writeJSON(w, "")
+		}
+	})
+}
+
+func TestProjectPlan(t *testing.T) {
+	description := "New"
+	desired := &ProjectState{
+		Description: &description,
+		Parent:      "All-Projects",
+		HEAD:        "main",
+		Config:      &ConfigInput{SubmitType: "INHERIT", RequireChangeID: "TRUE"},
+		Access: map[string]AccessSectionInfo{
+			"refs/heads/*":       {Permissions: map[string]PermissionInfo{"read": {Rules: map[string]PermissionRuleInfo{"g": {Action: PermissionActionAllow}}}}},
+			"refs/for/refs/*":    {Permissions: map[string]PermissionInfo{"push": {Rules: map[string]PermissionRuleInfo{"g": {Action: PermissionActionAllow}}}}},
+			"refs/meta/config":   {Permissions: map[string]PermissionInfo{"read": {}}},
+			"refs/heads/stable*": {},
+		},
+		Branches: map[string]string{"refs/heads/main": "master", "stable": "", "master": ""},
+		Prune:    true,
+	}
+
+	var updates []string
+	client := syncServer(t, map[string]bool{"PUT HEAD": true}, &updates)
+	plan, _, err := NewProject(client, "myProject").Plan(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+
+	var steps []string
+	for _, step := range plan.Steps {
+		steps = append(steps, step.String())
+	}
+	// The parent is unchanged, the submit type is inherited already and the HEAD branch is not pruned.
+	want := []string{
+		"~ description",
+		"~ config",
+		"+ access refs/for/refs/*",
+		"+ access refs/heads/stable*",
+		"+ access refs/meta/config",
+		"- access refs/tags/*",
+		"+ branch main",
+		"+ branch stable",
+		"~ HEAD",
+		"- branch old",
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("Plan() steps = %q, want %q", steps, want)
+	}
+	if s := plan.String(); !strings.Contains(s, "project myProject: 10 change(s)") || !strings.Contains(s, "      require_change_id: INHERIT -> TRUE") {
+		t.Errorf("Plan().String() = %s, want the steps and their details", s)
+	}
+
+	result := plan.Apply(context.Background())
+	if len(result.Applied) != 9 || len(result.Failed) != 1 || result.Failed[0].Step.Resource != "HEAD" {
+		t.Fatalf("Apply() = %d applied, failed %v, want only HEAD failing", len(result.Applied), result.Err())
+	}
+	var e *ErrorResponse
+	if err := result.Err(); !errors.As(err, &e) || !strings.HasPrefix(err.Error(), "~ HEAD: ") {
+		t.Errorf("Err() = %v, want the failure of the HEAD step", err)
+	}
+	// The step after the failing one is still applied.
+	if last := updates[len(updates)-1]; last != "DELETE branches/old" {
+		t.Errorf("last update = %s, want the deletion of the old branch", last)
+	}
+}
+
+func TestProjectPlanUpToDate(t *testing.T) {
+	var updates []string
+	client := syncServer(t, nil, &updates)
+	plan, result, err := NewProject(client, "myProject").Sync(context.Background(), &ProjectState{
+		Parent:   "All-Projects",
+		HEAD:     "refs/heads/master",
+		Config:   &ConfigInput{SubmitType: "INHERIT"},
+		Branches: map[string]string{"master": ""},
+	})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !plan.Empty() || plan.String() != "project myProject is up to date" || len(result.Applied) != 0 || len(updates) != 0 {
+		t.Errorf("Sync() = %s, applied %d, updates %v, want nothing to do", plan, len(result.Applied), updates)
+	}
+}
+
+func TestLabelChanges(t *testing.T) {
+	live := LabelDefinitionInfo{
+		Name:          "Verified",
+		Function:      "MaxWithBlock",
+		Values:        map[string]string{"-1": "Fails", " 0": "No score", "+1": "Verified"},
+		DefaultValue:  0,
+		CopyCondition: "is:MIN",
+	}
+	zero, yes, no := 0, true, false
+	tests := []struct {
+		name    string
+		desired LabelDefinitionInput
+		want    []string
+	}{
+		{"nothing set", LabelDefinitionInput{}, nil},
+		{
+			"same values",
+			LabelDefinitionInput{
+				Function:        "MaxWithBlock",
+				Values:          map[string]string{"-1": "Fails ", "0": "No score", "1": "Verified"},
+				DefaultValue:    &zero,
+				AllowPostSubmit: &no,
+			},
+			nil,
+		},
+		{
+			"changed values",
+			LabelDefinitionInput{
+				Function:    "NoBlock",
+				Values:      map[string]string{"-1": "Fails", "0": "No score"},
+				CanOverride: &yes,
+				Branches:    []string{"refs/heads/main"},
+			},
+			[]string{
+				"function: MaxWithBlock -> NoBlock",
+				"values: map[-1:Fails 0:No score 1:Verified] -> map[-1:Fails 0:No score]",
+				"branches: [] -> [refs/heads/main]",
+				"can_override: false -> true",
+			},
+		},
+		{"unset copy condition", LabelDefinitionInput{UnsetCopyCondition: &yes}, []string{"copy_condition: is:MIN -> "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := labelChanges(live, tt.desired); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("labelChanges() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := labelChanges(LabelDefinitionInfo{}, LabelDefinitionInput{UnsetCopyCondition: &yes}); got != nil {
+		t.Errorf("labelChanges() = %q, want no change to unset a missing copy condition", got)
+	}
+}
+
+func TestNormalizeLabelValues(t *testing.T) {
+	got := normalizeLabelValues(map[string]string{"-2": "Veto", " 0": " No score ", "+2": "Approved", "x": "ignored"})
+	want := map[int]string{-2: "Veto", 0: "No score", 2: "Approved"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeLabelValues() = %v, want %v", got, want)
+	}
+}
+
+func TestProjectPlanLabels(t *testing.T) {
+	var updates []string
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		request := r.Method + " " + r.URL.EscapedPath()
+		switch request {
+		case "GET /projects/myProject/labels/":
+			writeJSON(w, []LabelDefinitionInfo{
+				{Name: "Code-Review", Function: "MaxWithBlock", Values: map[string]string{"+1": "LGTM"}},
+				{Name: "Verified", Function: "MaxWithBlock"},
+				{Name: "Old"},
+			})
+		case "GET /projects/myProject/labels/Verified":
+			writeJSON(w, LabelDefinitionInfo{Name: "Verified"})
+		default:
+			updates = append(updates, request)
+			var in struct {
+				CommitMessage string `json:"commit_message"`
+			}
+			readJSON(t, r, &in)
+			if in.CommitMessage != "Sync labels" {
+				t.Errorf("%s commit message = %q, want the one of the state", request, in.CommitMessage)
+			}
+			if r.Method == "DELETE" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			writeJSON(w, LabelDefinitionInfo{})
+		}
+	})
+
+	plan, result, err := NewProject(client, "myProject").Sync(context.Background(), &ProjectState{
+		Labels: map[string]LabelDefinitionInput{
+			"Code-Review": {Values: map[string]string{"1": "LGTM"}},
+			"Verified":    {Function: "NoBlock"},
+			"Ready":       {Values: map[string]string{"+1": "Ready"}},
+		},
+		Prune:         true,
+		CommitMessage: "Sync labels",
+	})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	var steps []string
+	for _, step := range plan.Steps {
+		steps = append(steps, step.String())
+	}
+	if want := []string{"+ label Ready", "~ label Verified", "- label Old"}; !reflect.DeepEqual(steps, want) {
+		t.Errorf("Plan() steps = %q, want %q", steps, want)
+	}
+	want := []string{
+		"PUT /projects/myProject/labels/Ready",
+		"PUT /projects/myProject/labels/Verified",
+		"DELETE /projects/myProject/labels/Old",
+	}
+	if !reflect.DeepEqual(updates, want) || len(result.Applied) != 3 {
+		t.Errorf("updates = %q, want %q", updates, want)
+	}
+}
+
+func TestSubmitRequirementChanges(t *testing.T) {
+	live := SubmitRequirementInfo{Name: "Code-Review", SubmittabilityExpression: "label:Code-Review=MAX", AllowOverrideInChildProjects: true}
+	tests := []struct {
+		name    string
+		desired SubmitRequirementInput
+		want    []string
+	}{
+		{"same", SubmitRequirementInput{Name: "Code-Review", SubmittabilityExpression: "label:Code-Review=MAX", AllowOverrideInChildProjects: true}, nil},
+		{
+			// The whole requirement is replaced: fields left empty are cleared.
+			"changed",
+			SubmitRequirementInput{Name: "Code-Review", ApplicabilityExpression: "branch:main", SubmittabilityExpression: "label:Code-Review=MAX"},
+			[]string{"applicability_expression:  -> branch:main", "allow_override_in_child_projects: true -> false"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := submitRequirementChanges(live, tt.desired); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("submitRequirementChanges() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectPlanSubmitRequirements(t *testing.T) {
+	var updates []string
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		request := r.Method + " " + r.URL.EscapedPath()
+		switch request {
+		case "GET /projects/myProject/submit_requirements/":
+			writeJSON(w, []SubmitRequirementInfo{
+				{Name: "Code-Review", SubmittabilityExpression: "label:Code-Review=MAX"},
+				{Name: "Verified", SubmittabilityExpression: "label:Verified=MAX"},
+				{Name: "Old", SubmittabilityExpression: "is:true"},
+			})
+		case "GET /projects/myProject/submit_requirements/Verified":
+			writeJSON(w, SubmitRequirementInfo{Name: "Verified"})
+		default:
+			updates = append(updates, request)
+			if r.Method == "PUT" {
+				var in SubmitRequirementInput
+				readJSON(t, r, &in)
+				if in.Name == "" {
+					t.Errorf("%s input has no name", request)
+				}
+			}
+			writeJSON(w, SubmitRequirementInfo{})
+		}
+	})
+
+	plan, result, err := NewProject(client, "myProject").Sync(context.Background(), &ProjectState{
+		SubmitRequirements: map[string]SubmitRequirementInput{
+			"Code-Review": {SubmittabilityExpression: "label:Code-Review=MAX"},
+			"Verified":    {SubmittabilityExpression: "label:Verified=MAX -label:Verified=MIN"},
+			"No-Blocks":   {SubmittabilityExpression: "-has:unresolved"},
+		},
+		Prune: true,
+	})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	var steps []string
+	for _, step := range plan.Steps {
+		steps = append(steps, step.String())
+	}
+	if want := []string{"+ submit-requirement No-Blocks", "~ submit-requirement Verified", "- submit-requirement Old"}; !reflect.DeepEqual(steps, want) {
+		t.Errorf("Plan() steps = %q, want %q", steps, want)
+	}
+	want := []string{
+		"PUT /projects/myProject/submit_requirements/No-Blocks",
+		"PUT /projects/myProject/submit_requirements/Verified",
+		"DELETE /projects/myProject/submit_requirements/Old",
+	}
+	if !reflect.DeepEqual(updates, want) || len(result.Applied) != 3 {
+		t.Errorf("updates = %q, want %q", updates, want)
+	}
+}
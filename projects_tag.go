@@ -14,12 +14,25 @@ type Tag struct {
 	Base    string
 }
 
+// TagInput entity contains information for creating a tag.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#tag-input
 type TagInput struct {
 	//Ref      string `json:"ref"`
 	Revision string `json:"revision,omitempty"`
-	Message  string `json:"message,omitempty"`
+
+	// Message creates an annotated tag when set, a lightweight tag otherwise.
+	// Gerrit cannot sign tags; signed tags have to be pushed.
+	Message string `json:"message,omitempty"`
+
+	// ValidationOptions are passed as push options to the ref-operation validation listeners.
+	ValidationOptions map[string]string `json:"validation_options,omitempty"`
 }
 
+// TagInfo entity contains information about a tag.
+// The Message of a signed tag ends with its signature, see Signature.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#tag-info
 type TagInfo struct {
 	Ref       string        `json:"ref"`
 	Revision  string        `json:"revision"`
@@ -147,15 +160,13 @@ func (t *Tag) Poll(ctx context.Context) (*http.Response, error) {
 	return t.gerrit.Requester.Call(ctx, "GET", u, nil, t.Raw)
 }
 
+// Create creates the tag. The TagInfo returned by Gerrit is stored in t.Raw.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#create-tag
 func (t *Tag) Create(ctx context.Context, input *TagInput) (*Tag, *http.Response, error) {
 	u := fmt.Sprintf("projects/%s/tags/%s", url.QueryEscape(t.project.Base), url.QueryEscape(t.Base))
-	resp, err := t.gerrit.Requester.Call(ctx, "PUT", u, input, nil)
-
-	if err != nil {
-		return nil, resp, err
-	}
+	resp, err := t.gerrit.Requester.Call(ctx, "PUT", u, input, t.Raw)
 
-	resp, err = t.Poll(ctx)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -0,0 +1,316 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// AllProjects is the name of the root project every project inherits from.
+const AllProjects = "All-Projects"
+
+// ChildProjectOptions specifies the parameters to Project.ListChildren and Project.GetChild.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#list-child-projects
+type ChildProjectOptions struct {
+	// Recursive includes the children of the children, recursively.
+	Recursive bool `url:"recursive,omitempty"`
+}
+
+// ProjectTreeNode is a project of a ProjectTree.
+type ProjectTreeNode struct {
+	Project ProjectInfo
+
+	// Parent is nil for the root of the tree and for orphaned projects.
+	Parent *ProjectTreeNode
+
+	// Children are sorted by project name.
+	Children []*ProjectTreeNode
+}
+
+// Name returns the name of the project.
+func (n *ProjectTreeNode) Name() string {
+	return n.Project.Name
+}
+
+// Ancestors returns the projects the node inherits from, its parent first and the root last.
+func (n *ProjectTreeNode) Ancestors() []*ProjectTreeNode {
+	var ancestors []*ProjectTreeNode
+	seen := map[*ProjectTreeNode]bool{n: true}
+	for p := n.Parent; p != nil && !seen[p]; p = p.Parent {
+		seen[p] = true
+		ancestors = append(ancestors, p)
+	}
+	return ancestors
+}
+
+// Descendants returns the projects inheriting from the node, in depth-first order.
+func (n *ProjectTreeNode) Descendants() []*ProjectTreeNode {
+	var descendants []*ProjectTreeNode
+	walkProjectTree(n, 0, map[*ProjectTreeNode]bool{}, func(node *ProjectTreeNode, _ int) error {
+		if node != n {
+			descendants = append(descendants, node)
+		}
+		return nil
+	})
+	return descendants
+}
+
+// ProjectTree is the inheritance tree of the projects, as built by ProjectService.GetTree.
+type ProjectTree struct {
+	Root *ProjectTreeNode
+
+	// Orphans are the projects whose parent is not visible to the caller, with the subtrees below them.
+	Orphans []*ProjectTreeNode
+
+	// Cycles are projects whose chain of parents loops instead of reaching a root.
+	// Gerrit rejects such updates, so this only happens with inconsistent data.
+	Cycles []*ProjectTreeNode
+
+	nodes  map[string]*ProjectTreeNode
+	gerrit *Gerrit
+}
+
+// GetTree lists all projects, including hidden ones, and builds their inheritance tree from root down.
+// The root defaults to All-Projects.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#list-projects
+func (s *ProjectService) GetTree(ctx context.Context, root string) (*ProjectTree, *http.Response, error) {
+	if root == "" {
+		root = AllProjects
+	}
+
+	projects, resp, err := s.List(ctx, &ProjectOptions{Tree: true, Description: true, All: true})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	tree, err := buildProjectTree(projects, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	tree.gerrit = s.gerrit
+	return tree, resp, nil
+}
+
+// buildProjectTree links the projects to their parents. Projects are keyed by name, as returned by ProjectService.List.
+func buildProjectTree(projects map[string]ProjectInfo, root string) (*ProjectTree, error) {
+	tree := &ProjectTree{nodes: make(map[string]*ProjectTreeNode, len(projects))}
+	for name, info := range projects {
+		if info.Name == "" {
+			info.Name = name
+		}
+		tree.nodes[name] = &ProjectTreeNode{Project: info}
+	}
+
+	names := make([]string, 0, len(tree.nodes))
+	for name := range tree.nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var tops []*ProjectTreeNode
+	for _, name := range names {
+		node := tree.nodes[name]
+		parent, ok := tree.nodes[node.Project.Parent]
+		if node.Project.Parent == "" || !ok {
+			tops = append(tops, node)
+			continue
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+
+	tree.Root = tree.nodes[root]
+	if tree.Root == nil {
+		return nil, fmt.Errorf("project %s not found", root)
+	}
+
+	reached := make(map[*ProjectTreeNode]bool)
+	for _, top := range tops {
+		walkProjectTree(top, 0, reached, func(*ProjectTreeNode, int) error { return nil })
+		if top.Project.Parent != "" {
+			tree.Orphans = append(tree.Orphans, top)
+		}
+	}
+	for _, name := range names {
+		if node := tree.nodes[name]; !reached[node] {
+			tree.Cycles = append(tree.Cycles, node)
+		}
+	}
+	return tree, nil
+}
+
+// Find returns the node of a project, or nil when the project is not part of the tree.
+func (t *ProjectTree) Find(name string) *ProjectTreeNode {
+	return t.nodes[name]
+}
+
+// Walk calls fn for every project of the tree in depth-first order, parents before their children,
+// with the depth of the project below the root. Each project is visited once, even if the data contains cycles.
+// Walking stops at the first error returned by fn.
+func (t *ProjectTree) Walk(fn func(node *ProjectTreeNode, depth int) error) error {
+	return walkProjectTree(t.Root, 0, map[*ProjectTreeNode]bool{}, fn)
+}
+
+func walkProjectTree(node *ProjectTreeNode, depth int, seen map[*ProjectTreeNode]bool, fn func(*ProjectTreeNode, int) error) error {
+	if seen[node] {
+		return nil
+	}
+	seen[node] = true
+	if err := fn(node, depth); err != nil {
+		return err
+	}
+	for _, child := range node.Children {
+		if err := walkProjectTree(child, depth+1, seen, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Text formats the tree like the tree command does:
+//
+//	All-Projects
+//	├── All-Users
+//	└── platform
+//	    └── platform/build
+func (t *ProjectTree) Text() string {
+	var b strings.Builder
+	b.WriteString(t.Root.Name() + "\n")
+	writeProjectTreeText(&b, t.Root, "", map[*ProjectTreeNode]bool{t.Root: true})
+	return b.String()
+}
+
+func writeProjectTreeText(b *strings.Builder, node *ProjectTreeNode, prefix string, seen map[*ProjectTreeNode]bool) {
+	for i, child := range node.Children {
+		branch, indent := "├── ", "│   "
+		if i == len(node.Children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		b.WriteString(prefix + branch + child.Name() + "\n")
+		if !seen[child] {
+			seen[child] = true
+			writeProjectTreeText(b, child, prefix+indent, seen)
+		}
+	}
+}
+
+// DOT formats the tree as a Graphviz digraph, with an edge from every parent to its children,
+// e.g. to render it with "dot -Tsvg".
+func (t *ProjectTree) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph projects {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box];\n")
+	fmt.Fprintf(&b, "\t%s;\n", dotID(t.Root.Name()))
+	_ = t.Walk(func(node *ProjectTreeNode, _ int) error {
+		for _, child := range node.Children {
+			fmt.Fprintf(&b, "\t%s -> %s;\n", dotID(node.Name()), dotID(child.Name()))
+		}
+		return nil
+	})
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotID quotes a project name as a Graphviz ID.
+func dotID(name string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(name) + `"`
+}
+
+// ReparentChildren moves the direct children of a project, and so their subtrees, below a new parent with Project.SetParent.
+// The parent and commit message are taken from the input. A failure to move one child does not prevent moving the others;
+// the tree is updated for the children that were moved.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#set-project-parent
+func (t *ProjectTree) ReparentChildren(ctx context.Context, name string, input *ProjectParentInput) ([]ProjectOperationResult, error) {
+	node, parent := t.Find(name), t.Find(input.Parent)
+	if node == nil {
+		return nil, fmt.Errorf("project %s not found", name)
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("project %s not found", input.Parent)
+	}
+
+	children := append([]*ProjectTreeNode(nil), node.Children...)
+	results := make([]ProjectOperationResult, 0, len(children))
+	for _, child := range children {
+		result := ProjectOperationResult{Project: child.Name()}
+		switch {
+		case child.Parent == parent:
+			result.Skipped, result.Reason = true, "already a child of "+input.Parent
+		case child == parent:
+			result.Skipped, result.Reason = true, "project cannot be its own parent"
+		case parent.inherits(child):
+			result.Skipped, result.Reason = true, fmt.Sprintf("%s inherits from the project", input.Parent)
+		default:
+			_, _, result.Err = NewProject(t.gerrit, child.Name()).SetParent(ctx, input)
+			if result.Err == nil {
+				t.move(child, parent)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// inherits reports whether ancestor is one of the ancestors of the node.
+func (n *ProjectTreeNode) inherits(ancestor *ProjectTreeNode) bool {
+	for _, p := range n.Ancestors() {
+		if p == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// move links a node to a new parent, keeping children sorted by name.
+func (t *ProjectTree) move(node, parent *ProjectTreeNode) {
+	if old := node.Parent; old != nil {
+		for i, child := range old.Children {
+			if child == node {
+				old.Children = append(old.Children[:i], old.Children[i+1:]...)
+				break
+			}
+		}
+	}
+	node.Parent = parent
+	node.Project.Parent = parent.Name()
+	parent.Children = append(parent.Children, node)
+	sort.Slice(parent.Children, func(i, j int) bool {
+		return parent.Children[i].Name() < parent.Children[j].Name()
+	})
+}
+
+// ListChildren lists the direct child projects of a project, or all its descendants when recursive.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#list-child-projects
+func (p *Project) ListChildren(ctx context.Context, opt *ChildProjectOptions) (*[]ProjectInfo, *http.Response, error) {
+	u := fmt.Sprintf("projects/%s/children/", url.QueryEscape(p.Base))
+
+	v := &[]ProjectInfo{}
+	resp, err := p.gerrit.Requester.Call(ctx, "GET", u, opt, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// GetChild retrieves a child project. With the recursive option, a project that is a descendant of the project
+// is found too.
+//
+// Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-child-project
+func (p *Project) GetChild(ctx context.Context, childName string, opt *ChildProjectOptions) (*ProjectInfo, *http.Response, error) {
+	v := new(ProjectInfo)
+	u := fmt.Sprintf("projects/%s/children/%s", url.QueryEscape(p.Base), url.QueryEscape(childName))
+
+	resp, err := p.gerrit.Requester.Call(ctx, "GET", u, opt, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
@@ -0,0 +1,216 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// treeProjects is a project hierarchy with an orphan, whose parent is not visible, and a cycle.
+func treeProjects() map[string]ProjectInfo {
+	return map[string]ProjectInfo{
+		"All-Projects":   {},
+		"All-Users":      {Parent: "All-Projects"},
+		"platform":       {Parent: "All-Projects"},
+		"platform/build": {Parent: "platform"},
+		"platform/tools": {Parent: "platform"},
+		"secret/app":     {Parent: "secret"},
+		"secret/app/lib": {Parent: "secret/app"},
+		"loop/a":         {Parent: "loop/b"},
+		"loop/b":         {Parent: "loop/a"},
+	}
+}
+
+func nodeNames(nodes []*ProjectTreeNode) []string {
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.Name())
+	}
+	return names
+}
+
+func TestBuildProjectTree(t *testing.T) {
+	tree, err := buildProjectTree(treeProjects(), AllProjects)
+	if err != nil {
+		t.Fatalf("buildProjectTree() error = %v", err)
+	}
+
+	if got, want := nodeNames(tree.Root.Children), []string{"All-Users", "platform"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Root.Children = %v, want %v", got, want)
+	}
+	if got, want := nodeNames(tree.Orphans), []string{"secret/app"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Orphans = %v, want %v", got, want)
+	}
+	if got, want := nodeNames(tree.Cycles), []string{"loop/a", "loop/b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Cycles = %v, want %v", got, want)
+	}
+	if orphan := tree.Find("secret/app"); orphan.Parent != nil || len(orphan.Descendants()) != 1 {
+		t.Errorf("orphan = %+v, want no parent and its subtree", orphan)
+	}
+
+	build := tree.Find("platform/build")
+	if got, want := nodeNames(build.Ancestors()), []string{"platform", "All-Projects"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Ancestors() = %v, want %v", got, want)
+	}
+	if got, want := nodeNames(tree.Root.Descendants()), []string{"All-Users", "platform", "platform/build", "platform/tools"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Descendants() = %v, want %v", got, want)
+	}
+	// Ancestors and Descendants stop at the loop instead of running forever.
+	if got := nodeNames(tree.Find("loop/a").Ancestors()); !reflect.DeepEqual(got, []string{"loop/b"}) {
+		t.Errorf("Ancestors() in a cycle = %v, want [loop/b]", got)
+	}
+	if got := nodeNames(tree.Find("loop/a").Descendants()); !reflect.DeepEqual(got, []string{"loop/b"}) {
+		t.Errorf("Descendants() in a cycle = %v, want [loop/b]", got)
+	}
+	if tree.Find("unknown") != nil {
+		t.Error("Find() of an unknown project is not nil")
+	}
+
+	if _, err := buildProjectTree(treeProjects(), "missing"); err == nil {
+		t.Error("buildProjectTree() with a missing root error = nil, want an error")
+	}
+	sub, err := buildProjectTree(treeProjects(), "platform")
+	if err != nil || sub.Root.Name() != "platform" {
+		t.Errorf("buildProjectTree() below platform = %+v, %v", sub, err)
+	}
+}
+
+func TestProjectTreeWalk(t *testing.T) {
+	tree, _ := buildProjectTree(treeProjects(), AllProjects)
+
+	var visited []string
+	err := tree.Walk(func(node *ProjectTreeNode, depth int) error {
+		visited = append(visited, strings.Repeat("-", depth)+node.Name())
+		return nil
+	})
+	want := []string{"All-Projects", "-All-Users", "-platform", "--platform/build", "--platform/tools"}
+	if err != nil || !reflect.DeepEqual(visited, want) {
+		t.Errorf("Walk() visited %v, %v, want %v", visited, err, want)
+	}
+
+	stop := errors.New("stop")
+	visited = nil
+	err = tree.Walk(func(node *ProjectTreeNode, _ int) error {
+		visited = append(visited, node.Name())
+		if node.Name() == "platform" {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || len(visited) != 3 {
+		t.Errorf("Walk() = %v after %v, want to stop at platform", err, visited)
+	}
+}
+
+func TestProjectTreeFormats(t *testing.T) {
+	tree, _ := buildProjectTree(treeProjects(), AllProjects)
+
+	text := "All-Projects\n" +
+		"├── All-Users\n" +
+		"└── platform\n" +
+		"    ├── platform/build\n" +
+		"    └── platform/tools\n"
+	if got := tree.Text(); got != text {
+		t.Errorf("Text() =\n%s\nwant\n%s", got, text)
+	}
+
+	dot := "digraph projects {\n" +
+		"\trankdir=LR;\n" +
+		"\tnode [shape=box];\n" +
+		"\t\"All-Projects\";\n" +
+		"\t\"All-Projects\" -> \"All-Users\";\n" +
+		"\t\"All-Projects\" -> \"platform\";\n" +
+		"\t\"platform\" -> \"platform/build\";\n" +
+		"\t\"platform\" -> \"platform/tools\";\n" +
+		"}\n"
+	if got := tree.DOT(); got != dot {
+		t.Errorf("DOT() =\n%s\nwant\n%s", got, dot)
+	}
+	if got := dotID(`a "b" \c`); got != `"a \"b\" \\c"` {
+		t.Errorf("dotID() = %s", got)
+	}
+
+	cycle, _ := buildProjectTree(treeProjects(), "loop/a")
+	if got := cycle.Text(); got != "loop/a\n└── loop/b\n    └── loop/a\n" {
+		t.Errorf("Text() of a cycle = %q, want the loop printed once", got)
+	}
+}
+
+func TestGetTreeAndReparentChildren(t *testing.T) {
+	var moved []string
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "GET /projects/":
+			if q := r.URL.Query(); q.Get("t") == "" || q.Get("all") == "" {
+				t.Errorf("query = %s, want the tree of all projects", r.URL.RawQuery)
+			}
+			writeJSON(w, treeProjects())
+		case "PUT /projects/platform%2Fbuild/parent":
+			http.Error(w, "not permitted", http.StatusForbidden)
+		case "PUT /projects/platform%2Ftools/parent", "PUT /projects/All-Users/parent":
+			var in ProjectParentInput
+			readJSON(t, r, &in)
+			moved = append(moved, in.Parent)
+			writeJSON(w, in.Parent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	})
+
+	tree, _, err := client.Projects.GetTree(context.Background(), "")
+	if err != nil {
+		t.Fatalf("GetTree() error = %v", err)
+	}
+	if tree.Root.Name() != AllProjects || len(tree.Orphans) != 1 {
+		t.Fatalf("GetTree() = %+v, want the tree below All-Projects", tree)
+	}
+
+	results, err := tree.ReparentChildren(context.Background(), "platform", &ProjectParentInput{Parent: "All-Users"})
+	if err != nil {
+		t.Fatalf("ReparentChildren() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Err == nil || results[1].Err != nil {
+		t.Fatalf("ReparentChildren() = %+v, want platform/build failing and platform/tools moved", results)
+	}
+	if !reflect.DeepEqual(moved, []string{"All-Users"}) {
+		t.Errorf("moved = %v, want platform/tools below All-Users", moved)
+	}
+	if got := nodeNames(tree.Find("All-Users").Children); !reflect.DeepEqual(got, []string{"platform/tools"}) {
+		t.Errorf("All-Users children = %v, want the moved project", got)
+	}
+	if got := nodeNames(tree.Find("platform").Children); !reflect.DeepEqual(got, []string{"platform/build"}) {
+		t.Errorf("platform children = %v, want the project that failed to move", got)
+	}
+
+	tests := []struct {
+		name, parent string
+		want         []string
+	}{
+		{AllProjects, "platform/build", []string{"All-Users: moved", "platform: platform/build inherits from the project"}},
+		{"platform", "platform", []string{"platform/build: already a child of platform"}},
+		{"platform", "platform/build", []string{"platform/build: project cannot be its own parent"}},
+	}
+	for _, tt := range tests {
+		results, err := tree.ReparentChildren(context.Background(), tt.name, &ProjectParentInput{Parent: tt.parent})
+		if err != nil {
+			t.Fatalf("ReparentChildren() error = %v", err)
+		}
+		var got []string
+		for _, result := range results {
+			outcome := "moved"
+			if result.Skipped {
+				outcome = result.Reason
+			}
+			got = append(got, result.Project+": "+outcome)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ReparentChildren(%s, %s) = %q, want %q", tt.name, tt.parent, got, tt.want)
+		}
+	}
+	if _, err := tree.ReparentChildren(context.Background(), "missing", &ProjectParentInput{Parent: "platform"}); err == nil {
+		t.Error("ReparentChildren() of a missing project error = nil, want an error")
+	}
+}
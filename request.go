@@ -10,7 +10,6 @@ import (
 	"log"
 	"net/http"
 	"net/url"
-	"reflect"
 	"strings"
 	"time"
 )
@@ -124,11 +123,30 @@ func (r *Requester) NewRequest(ctx context.Context, method, endpoint string, opt
 	}
 
 	if opt != nil && (method == http.MethodPost || method == http.MethodPut) {
-		if reflect.TypeOf(opt).String() == "string" {
-			req.Body = io.NopCloser(bytes.NewBuffer([]byte(opt.(string))))
+		switch body := opt.(type) {
+		case string:
+			req.Body = io.NopCloser(bytes.NewBuffer([]byte(body)))
+
+			req.Header.Add("Content-Type", "text/plain;charset=UTF-8")
+		case []byte, *bytes.Buffer, *bytes.Reader, *strings.Reader:
+			// As with http.NewRequest, in-memory bodies are sent with a length and can be replayed, e.g. on redirects.
+			reader, ok := opt.(io.Reader)
+			if !ok {
+				reader = bytes.NewReader(opt.([]byte))
+			}
+			sized, err := http.NewRequestWithContext(ctx, method, urlStr, reader)
+			if err != nil {
+				return nil, err
+			}
+			req.Body, req.GetBody, req.ContentLength = sized.Body, sized.GetBody, sized.ContentLength
 
-			req.Header.Add("Content-Type", "plain/text;charset=UTF-8")
-		} else {
+			req.Header.Set("Content-Type", "application/octet-stream")
+		case io.Reader:
+			// Other readers are streamed once, with chunked transfer encoding.
+			req.Body = io.NopCloser(body)
+
+			req.Header.Set("Content-Type", "application/octet-stream")
+		default:
 			buf, err := json.Marshal(opt)
 			//log.Printf("buf: %+v", buf)
 			if err != nil {
@@ -196,6 +214,11 @@ func (r *Requester) Do(req *http.Request, v interface{}) (*http.Response, error)
 	if v != nil {
 		defer resp.Body.Close()
 
+		// A conditional request was answered without a body, leave v untouched.
+		if resp.StatusCode == http.StatusNotModified {
+			return resp, nil
+		}
+
 		if w, ok := v.(io.Writer); ok {
 			if _, err := io.Copy(w, resp.Body); err != nil {
 				return nil, err
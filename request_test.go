@@ -0,0 +1,83 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewRequestBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        interface{}
+		want        string
+		contentType string
+		length      int64
+		replayable  bool
+	}{
+		{"string", "text", "text", "text/plain;charset=UTF-8", 0, false},
+		{"bytes", []byte{0, 1, 2}, "\x00\x01\x02", "application/octet-stream", 3, true},
+		{"bytes.Buffer", bytes.NewBufferString("buffer"), "buffer", "application/octet-stream", 6, true},
+		{"bytes.Reader", bytes.NewReader([]byte("reader")), "reader", "application/octet-stream", 6, true},
+		{"strings.Reader", strings.NewReader("strings"), "strings", "application/octet-stream", 7, true},
+		{"other reader", io.MultiReader(strings.NewReader("stream")), "stream", "application/octet-stream", 0, false},
+		{"JSON", map[string]string{"a": "b"}, `{"a":"b"}`, "application/json", 0, false},
+	}
+
+	r := &Requester{client: http.DefaultClient}
+	r.baseURL, _ = SetBaseURL("https://gerrit.example.com/")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := r.NewRequest(context.Background(), http.MethodPut, "changes/42/edit/file", tt.body)
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v", err)
+			}
+			if got := req.Header.Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+			if req.ContentLength != tt.length {
+				t.Errorf("ContentLength = %d, want %d", req.ContentLength, tt.length)
+			}
+			if (req.GetBody != nil) != tt.replayable {
+				t.Errorf("GetBody set = %v, want %v", req.GetBody != nil, tt.replayable)
+			}
+
+			body, _ := io.ReadAll(req.Body)
+			if string(body) != tt.want {
+				t.Errorf("body = %q, want %q", body, tt.want)
+			}
+			if req.GetBody != nil {
+				replay, err := req.GetBody()
+				if err != nil {
+					t.Fatalf("GetBody() error = %v", err)
+				}
+				if body, _ := io.ReadAll(replay); string(body) != tt.want {
+					t.Errorf("replayed body = %q, want %q", body, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewRequestBodyRedirect(t *testing.T) {
+	var got []string
+	client := newTestGerrit(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, r.URL.Path+" "+string(body))
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusTemporaryRedirect)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Requester.Call(context.Background(), http.MethodPost, "old", []byte("content"), nil); err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if len(got) != 2 || got[1] != "/new content" {
+		t.Errorf("requests = %q, want the body resent after the redirect", got)
+	}
+}
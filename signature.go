@@ -0,0 +1,271 @@
+package gerrit
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Signature formats of GpgSignature, named like git's gpg.format setting.
+const (
+	SignatureFormatOpenPGP = "openpgp"
+	SignatureFormatX509    = "x509"
+	SignatureFormatSSH     = "ssh"
+)
+
+// signatureFormats maps the armor headers git recognizes to signature formats.
+var signatureFormats = []struct {
+	begin, end, format string
+}{
+	{"-----BEGIN PGP SIGNATURE-----", "-----END PGP SIGNATURE-----", SignatureFormatOpenPGP},
+	{"-----BEGIN PGP MESSAGE-----", "-----END PGP MESSAGE-----", SignatureFormatOpenPGP},
+	{"-----BEGIN SIGNED MESSAGE-----", "-----END SIGNED MESSAGE-----", SignatureFormatX509},
+	{"-----BEGIN SSH SIGNATURE-----", "-----END SSH SIGNATURE-----", SignatureFormatSSH},
+}
+
+// GpgSignature is the signature of a signed tag, as found at the end of its message.
+// The signature is parsed, not verified.
+type GpgSignature struct {
+	// Format is the signature format: SignatureFormatOpenPGP, SignatureFormatX509 or SignatureFormatSSH.
+	Format string
+
+	// Armored is the signature block, including its BEGIN and END lines.
+	Armored string
+
+	// KeyID is the 16 hex digit ID of the OpenPGP key that made the signature, and Fingerprint its fingerprint when
+	// the signature includes it. Both are upper case and empty for other formats.
+	KeyID       string
+	Fingerprint string
+
+	// Created is when an OpenPGP signature was made.
+	Created time.Time
+}
+
+// ParseGpgSignature splits a tag message into the message itself and its trailing signature.
+// The signature is nil when the message is not signed. An error is returned when an OpenPGP signature cannot be parsed;
+// the message and the armored signature are returned anyway.
+func ParseGpgSignature(message string) (string, *GpgSignature, error) {
+	for _, f := range signatureFormats {
+		start := strings.LastIndex(message, f.begin)
+		if start < 0 || (start > 0 && message[start-1] != '\n') {
+			continue
+		}
+		end := strings.Index(message[start:], f.end)
+		if end < 0 {
+			continue
+		}
+
+		sig := &GpgSignature{Format: f.format, Armored: message[start : start+end+len(f.end)]}
+		if f.format == SignatureFormatOpenPGP {
+			if err := sig.parseOpenPGP(); err != nil {
+				return message[:start], sig, err
+			}
+		}
+		return message[:start], sig, nil
+	}
+	return message, nil, nil
+}
+
+// Signature returns the signature of a signed tag, or nil when the tag is not signed.
+func (t *TagInfo) Signature() (*GpgSignature, error) {
+	_, sig, err := ParseGpgSignature(t.Message)
+	return sig, err
+}
+
+// MatchesKey reports whether the signature was made by a GPG key, as registered on a Gerrit account.
+// Only the key IDs are compared; the signature itself is not verified.
+func (s *GpgSignature) MatchesKey(key GpgKeyInfo) bool {
+	if s.KeyID == "" {
+		return false
+	}
+	fingerprint := strings.ToUpper(strings.ReplaceAll(key.Fingerprint, " ", ""))
+	if s.Fingerprint != "" && fingerprint != "" {
+		return s.Fingerprint == fingerprint
+	}
+	id := strings.ToUpper(key.ID)
+	if id == "" {
+		id = fingerprintKeyID(fingerprint)
+	}
+	return id != "" && strings.HasSuffix(s.KeyID, id)
+}
+
+// fingerprintKeyID derives the key ID from a hex fingerprint: its low 64 bits for version 4 keys,
+// its high 64 bits for the 32 byte fingerprints of version 5 keys.
+func fingerprintKeyID(fingerprint string) string {
+	switch len(fingerprint) {
+	case 40:
+		return fingerprint[24:]
+	case 64:
+		return fingerprint[:16]
+	}
+	return ""
+}
+
+// parseOpenPGP decodes the armored OpenPGP signature packet and extracts the issuer and creation time.
+func (s *GpgSignature) parseOpenPGP() error {
+	lines := strings.Split(strings.ReplaceAll(s.Armored, "\r\n", "\n"), "\n")
+	var (
+		b64     strings.Builder
+		headers = true
+	)
+	for _, line := range lines[1 : len(lines)-1] {
+		line = strings.TrimSpace(line)
+		switch {
+		case headers:
+			// Armor headers like "Version: GnuPG v2" end with an empty line.
+			if line == "" {
+				headers = false
+			} else if !strings.Contains(line, ": ") {
+				headers = false
+				b64.WriteString(line)
+			}
+		case strings.HasPrefix(line, "="):
+			// The CRC-24 checksum.
+		default:
+			b64.WriteString(line)
+		}
+	}
+
+	data, err := base64.StdEncoding.DecodeString(b64.String())
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+	body, err := openPGPPacket(data)
+	if err != nil {
+		return err
+	}
+	return s.parseSignaturePacket(body)
+}
+
+// openPGPPacket returns the body of the first packet, which must be a signature packet.
+func openPGPPacket(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0]&0x80 == 0 {
+		return nil, errors.New("invalid signature packet")
+	}
+
+	var tag, length, offset int
+	if data[0]&0x40 != 0 {
+		// New format packet.
+		tag = int(data[0] & 0x3f)
+		switch l := int(data[1]); {
+		case l < 192:
+			length, offset = l, 2
+		case l < 224 && len(data) >= 3:
+			length, offset = (l-192)<<8+int(data[2])+192, 3
+		case l == 255 && len(data) >= 6:
+			length, offset = int(binary.BigEndian.Uint32(data[2:6])), 6
+		default:
+			return nil, errors.New("unsupported signature packet length")
+		}
+	} else {
+		// Old format packet.
+		tag = int(data[0]>>2) & 0x0f
+		switch data[0] & 0x03 {
+		case 0:
+			length, offset = int(data[1]), 2
+		case 1:
+			if len(data) < 3 {
+				return nil, errors.New("truncated signature packet")
+			}
+			length, offset = int(binary.BigEndian.Uint16(data[1:3])), 3
+		case 2:
+			if len(data) < 5 {
+				return nil, errors.New("truncated signature packet")
+			}
+			length, offset = int(binary.BigEndian.Uint32(data[1:5])), 5
+		default:
+			length, offset = len(data)-1, 1
+		}
+	}
+
+	if tag != 2 {
+		return nil, fmt.Errorf("packet %d is not a signature", tag)
+	}
+	if offset+length > len(data) {
+		return nil, errors.New("truncated signature packet")
+	}
+	return data[offset : offset+length], nil
+}
+
+// parseSignaturePacket parses a version 3, 4 or 5 signature packet.
+func (s *GpgSignature) parseSignaturePacket(body []byte) error {
+	if len(body) == 0 {
+		return errors.New("empty signature packet")
+	}
+
+	switch body[0] {
+	case 3:
+		if len(body) < 15 {
+			return errors.New("truncated signature packet")
+		}
+		s.Created = time.Unix(int64(binary.BigEndian.Uint32(body[3:7])), 0).UTC()
+		s.KeyID = strings.ToUpper(hex.EncodeToString(body[7:15]))
+		return nil
+	case 4, 5:
+		// Version, signature type, public key and hash algorithm precede the hashed subpackets.
+		if len(body) < 4 {
+			return errors.New("truncated signature packet")
+		}
+		rest := body[4:]
+		for i := 0; i < 2; i++ {
+			if len(rest) < 2 {
+				return errors.New("truncated signature packet")
+			}
+			n := int(binary.BigEndian.Uint16(rest))
+			if len(rest) < 2+n {
+				return errors.New("truncated signature packet")
+			}
+			if err := s.parseSubpackets(rest[2 : 2+n]); err != nil {
+				return err
+			}
+			rest = rest[2+n:]
+		}
+		if s.KeyID == "" {
+			s.KeyID = fingerprintKeyID(s.Fingerprint)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported signature version %d", body[0])
+}
+
+// parseSubpackets extracts the creation time, issuer and issuer fingerprint subpackets.
+func (s *GpgSignature) parseSubpackets(data []byte) error {
+	for len(data) > 0 {
+		var length, offset int
+		switch l := int(data[0]); {
+		case l < 192:
+			length, offset = l, 1
+		case l < 255 && len(data) >= 2:
+			length, offset = (l-192)<<8+int(data[1])+192, 2
+		case l == 255 && len(data) >= 5:
+			length, offset = int(binary.BigEndian.Uint32(data[1:5])), 5
+		default:
+			return errors.New("truncated signature subpacket")
+		}
+		if length == 0 || offset+length > len(data) {
+			return errors.New("truncated signature subpacket")
+		}
+
+		packet := data[offset : offset+length]
+		switch value := packet[1:]; packet[0] & 0x7f {
+		case 2:
+			if len(value) == 4 {
+				s.Created = time.Unix(int64(binary.BigEndian.Uint32(value)), 0).UTC()
+			}
+		case 16:
+			if len(value) == 8 {
+				s.KeyID = strings.ToUpper(hex.EncodeToString(value))
+			}
+		case 33:
+			if len(value) > 1 {
+				s.Fingerprint = strings.ToUpper(hex.EncodeToString(value[1:]))
+			}
+		}
+		data = data[offset+length:]
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package gerrit
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+)
+
+// gpgSignedTag is the message of a tag signed with "git tag -s" and an ed25519 key,
+// whose signature has an issuer fingerprint, a creation time and an issuer key ID subpacket.
+const gpgSignedTag = `Release 1.0
+-----BEGIN PGP SIGNATURE-----
+
+iIoEABYIADIWIQS7W0Ta9XcMTNwPw500mO4Slf+plgUCatS/JxQccmVsZWFzZUBl
+eGFtcGxlLmNvbQAKCRA0mO4Slf+plqc1AP9jx3xNAnDubcZ8Ogp88E7v/YVtbXuK
+I/Ly14E5aFROaQD/fNOgtvKvz+qJR4mlARltQfAy6/8FboZFxfHT41fAIA8=
+=gvLe
+-----END PGP SIGNATURE-----
+`
+
+const sshSignedTag = `Release 1.0
+-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAgL/Bfm156TnJD0r3bHYX+E4FsE8
+ee8pmI7qaq4GxlCb4AAAADZ2l0AAAAAAAAAAZzaGE1MTIAAABTAAAAC3NzaC1lZDI1NTE5
+AAAAQFyprnS8NqVUkxx+BUURta19zqgMlqMSFJR+CLrS4ViaheSbnWDQwj8kmvOzDgwa3b
+2ScWhe0EXabpytNlRUlQw=
+-----END SSH SIGNATURE-----
+`
+
+const x509SignedTag = `Release 1.0
+-----BEGIN SIGNED MESSAGE-----
+MIAGCSqGSIb3DQEHAqCAMIACAQExDTALBglghkgBZQMEAgEwCwYJKoZIhvcNAQcB
+-----END SIGNED MESSAGE-----
+`
+
+// armorPGP wraps an OpenPGP packet into a signature block.
+func armorPGP(packet []byte) string {
+	return "-----BEGIN PGP SIGNATURE-----\n\n" + base64.StdEncoding.EncodeToString(packet) + "\n-----END PGP SIGNATURE-----\n"
+}
+
+// v3SignaturePacket builds an old format version 3 signature packet.
+func v3SignaturePacket(created uint32, keyID string) []byte {
+	body := []byte{3, 5, 0x00}
+	body = binary.BigEndian.AppendUint32(body, created)
+	id, _ := hex.DecodeString(keyID)
+	body = append(body, id...)
+	body = append(body, 1, 8, 0xab, 0xcd, 0x00, 0x08, 0xff)
+	return append([]byte{0x88, byte(len(body))}, body...)
+}
+
+// v4SignaturePacket builds a new format version 4 signature packet with a creation time and
+// an issuer fingerprint, but without an issuer key ID subpacket.
+func v4SignaturePacket(created uint32, fingerprint string) []byte {
+	fpr, _ := hex.DecodeString(fingerprint)
+	hashed := []byte{5, 2}
+	hashed = binary.BigEndian.AppendUint32(hashed, created)
+	hashed = append(hashed, byte(2+len(fpr)), 33, 4)
+	hashed = append(hashed, fpr...)
+
+	body := []byte{4, 0x00, 22, 8}
+	body = binary.BigEndian.AppendUint16(body, uint16(len(hashed)))
+	body = append(body, hashed...)
+	body = append(body, 0, 0, 0xab, 0xcd)
+	return append([]byte{0xc2, byte(len(body))}, body...)
+}
+
+func TestParseGpgSignature(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     string
+		wantMessage string
+		wantFormat  string
+		keyID       string
+		fingerprint string
+		created     time.Time
+		wantErr     string
+	}{
+		{
+			name:        "gpg signed tag",
+			message:     gpgSignedTag,
+			wantMessage: "Release 1.0\n",
+			wantFormat:  SignatureFormatOpenPGP,
+			keyID:       "3498EE1295FFA996",
+			fingerprint: "BB5B44DAF5770C4CDC0FC39D3498EE1295FFA996",
+			created:     time.Unix(1792327463, 0).UTC(),
+		},
+		{
+			name:        "version 3 signature",
+			message:     "v3\n" + armorPGP(v3SignaturePacket(1700000000, "0123456789ABCDEF")),
+			wantMessage: "v3\n",
+			wantFormat:  SignatureFormatOpenPGP,
+			keyID:       "0123456789ABCDEF",
+			created:     time.Unix(1700000000, 0).UTC(),
+		},
+		{
+			name:        "version 4 signature without issuer key ID",
+			message:     "v4\n" + armorPGP(v4SignaturePacket(1700000000, "BB5B44DAF5770C4CDC0FC39D3498EE1295FFA996")),
+			wantMessage: "v4\n",
+			wantFormat:  SignatureFormatOpenPGP,
+			keyID:       "3498EE1295FFA996",
+			fingerprint: "BB5B44DAF5770C4CDC0FC39D3498EE1295FFA996",
+			created:     time.Unix(1700000000, 0).UTC(),
+		},
+		{
+			name:        "truncated version 4 packet",
+			message:     "short\n" + armorPGP([]byte{0xc2, 2, 4, 0}),
+			wantMessage: "short\n",
+			wantFormat:  SignatureFormatOpenPGP,
+			wantErr:     "truncated signature packet",
+		},
+		{
+			name:        "truncated packet length",
+			message:     "short\n" + armorPGP([]byte{0xc2, 20, 4, 0, 22, 8}),
+			wantMessage: "short\n",
+			wantFormat:  SignatureFormatOpenPGP,
+			wantErr:     "truncated signature packet",
+		},
+		{
+			name:        "not a signature packet",
+			message:     "key\n" + armorPGP([]byte{0xc6, 1, 4}),
+			wantMessage: "key\n",
+			wantFormat:  SignatureFormatOpenPGP,
+			wantErr:     "packet 6 is not a signature",
+		},
+		{
+			name:        "ssh signature",
+			message:     sshSignedTag,
+			wantMessage: "Release 1.0\n",
+			wantFormat:  SignatureFormatSSH,
+		},
+		{
+			name:        "x509 signature",
+			message:     x509SignedTag,
+			wantMessage: "Release 1.0\n",
+			wantFormat:  SignatureFormatX509,
+		},
+		{
+			name:        "unsigned message",
+			message:     "Release 1.0\n\nMentions -----BEGIN PGP SIGNATURE----- inline.\n",
+			wantMessage: "Release 1.0\n\nMentions -----BEGIN PGP SIGNATURE----- inline.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, sig, err := ParseGpgSignature(tt.message)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("ParseGpgSignature() error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("ParseGpgSignature() error = %v", err)
+			}
+			if message != tt.wantMessage {
+				t.Errorf("ParseGpgSignature() message = %q, want %q", message, tt.wantMessage)
+			}
+
+			if tt.wantFormat == "" {
+				if sig != nil {
+					t.Errorf("ParseGpgSignature() signature = %+v, want nil", sig)
+				}
+				return
+			}
+			if sig == nil {
+				t.Fatal("ParseGpgSignature() signature = nil")
+			}
+			if sig.Format != tt.wantFormat {
+				t.Errorf("Format = %q, want %q", sig.Format, tt.wantFormat)
+			}
+			if !strings.HasPrefix(sig.Armored, "-----BEGIN ") || !strings.HasSuffix(sig.Armored, "-----") {
+				t.Errorf("Armored = %q, want a complete signature block", sig.Armored)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if sig.KeyID != tt.keyID || sig.Fingerprint != tt.fingerprint || !sig.Created.Equal(tt.created) {
+				t.Errorf("signature = key ID %q, fingerprint %q, created %v, want %q, %q, %v",
+					sig.KeyID, sig.Fingerprint, sig.Created, tt.keyID, tt.fingerprint, tt.created)
+			}
+		})
+	}
+}
+
+func TestGpgSignatureMatchesKey(t *testing.T) {
+	_, sig, err := ParseGpgSignature(gpgSignedTag)
+	if err != nil {
+		t.Fatalf("ParseGpgSignature() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		key  GpgKeyInfo
+		want bool
+	}{
+		{"same fingerprint", GpgKeyInfo{Fingerprint: "BB5B 44DA F577 0C4C DC0F  C39D 3498 EE12 95FF A996"}, true},
+		{"other fingerprint", GpgKeyInfo{ID: "3498EE1295FFA996", Fingerprint: "0000 44DA F577 0C4C DC0F  C39D 3498 EE12 95FF A996"}, false},
+		{"same key ID", GpgKeyInfo{ID: "3498ee1295ffa996"}, true},
+		{"short key ID", GpgKeyInfo{ID: "95FFA996"}, true},
+		{"other key ID", GpgKeyInfo{ID: "0123456789ABCDEF"}, false},
+		{"no key ID", GpgKeyInfo{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sig.MatchesKey(tt.key); got != tt.want {
+				t.Errorf("MatchesKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}